@@ -0,0 +1,147 @@
+// Package ai implements behaviour trees which decide what monsters do during their turn.
+package ai
+
+import (
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/pathfinding"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// Status is the result of ticking a Node.
+type Status int
+
+// List of Status values.
+const (
+	StatusFailure Status = iota
+	StatusSuccess
+)
+
+func (d Status) String() string {
+	return [...]string{"Failure", "Success"}[d]
+}
+
+// World is the view of the game world the behaviours are allowed to use.
+type World interface {
+	Entities() []*entity.Entity
+	// Relation returns how entity a regards entity b.
+	Relation(a, b *entity.Entity) components.Relation
+	// Leader returns the entity e follows or nil if it does not follow anyone.
+	Leader(e *entity.Entity) *entity.Entity
+
+	Distance(a utils.Vec2, b utils.Vec2) float64
+	// Path returns the path of e from its position to goal without the start position.
+	// It avoids the traps e knows about.
+	Path(e *entity.Entity, goal utils.Vec2) []utils.Vec2
+	// Graph returns the map as seen by e for the path finding.
+	Graph(e *entity.Entity) pathfinding.Graph
+	Obstacles() pathfinding.Obstacles
+	// Walkable returns true if an entity can step onto p.
+	Walkable(p utils.Vec2) bool
+	Neighbors(p utils.Vec2) []utils.Vec2
+}
+
+// Context is passed to all nodes of a behaviour tree during the turn of an entity.
+type Context struct {
+	Entity *entity.Entity
+	World  World
+
+	// Target is the closest hostile entity the entity noticed in this turn
+	Target *entity.Entity
+	// Threat is the closest entity the entity is afraid of and noticed in this turn
+	Threat *entity.Entity
+
+	// NextPosition is the position the entity wants to move to or attack in this turn
+	NextPosition *utils.Vec2
+}
+
+// Node is a node of a behaviour tree.
+type Node interface {
+	Tick(ctx *Context) Status
+}
+
+// Decide runs the behaviour tree for e and returns the position it wants to move to.
+// The second return value is false if the entity does not want to move.
+func Decide(tree Node, e *entity.Entity, w World) (utils.Vec2, bool) {
+	ctx := &Context{Entity: e, World: w}
+	perceive(ctx)
+	tree.Tick(ctx)
+	if ctx.NextPosition == nil {
+		return utils.Vec2{}, false
+	}
+	return *ctx.NextPosition, true
+}
+
+func (ctx *Context) moveTo(p utils.Vec2) Status {
+	ctx.NextPosition = &p
+	return StatusSuccess
+}
+
+// moveAlong moves one step along the path to goal.
+func (ctx *Context) moveAlong(goal utils.Vec2) Status {
+	path := ctx.World.Path(ctx.Entity, goal)
+	if len(path) == 0 {
+		return StatusFailure
+	}
+	return ctx.moveTo(path[0])
+}
+
+// moveAway moves one step away from threat, if there is a step which increases the distance.
+func (ctx *Context) moveAway(threat utils.Vec2) Status {
+	current := ctx.Entity.Position.Current
+	best := current
+	bestDistance := ctx.World.Distance(current, threat)
+	for _, n := range ctx.World.Neighbors(current) {
+		if !ctx.World.Walkable(n) {
+			continue
+		}
+		if d := ctx.World.Distance(n, threat); d > bestDistance {
+			best = n
+			bestDistance = d
+		}
+	}
+	if best.Equal(current) {
+		return StatusFailure
+	}
+	return ctx.moveTo(best)
+}
+
+// home returns the position the entity stays around, which is its leader or otherwise its spawn position.
+func (ctx *Context) home() utils.Vec2 {
+	if leader := ctx.World.Leader(ctx.Entity); leader != nil && leader.Position != nil {
+		return leader.Position.Current
+	}
+	return ctx.Entity.Position.Initial
+}
+
+// combatantNear returns the living entity able to fight which is closest to p,
+// but at most one step away from it. It ignores the entity itself and its leader.
+func (ctx *Context) combatantNear(p utils.Vec2) *entity.Entity {
+	leader := ctx.World.Leader(ctx.Entity)
+	var nearest *entity.Entity
+	nearestDistance := 1.5
+	for _, other := range ctx.World.Entities() {
+		if other == ctx.Entity || other == leader || other.Combat == nil || other.Position == nil || other.IsDead != nil {
+			continue
+		}
+		if d := ctx.World.Distance(p, other.Position.Current); d <= nearestDistance {
+			nearest = other
+			nearestDistance = d
+		}
+	}
+	return nearest
+}
+
+// forgetTarget clears the memory of the entity and its pack about the position of the target.
+func (ctx *Context) forgetTarget() {
+	e := ctx.Entity
+	e.AI.LastKnownTargetPosition = nil
+	if e.AI.Pack != nil {
+		e.AI.Pack.LastKnownTargetPosition = nil
+	}
+}
+
+// inRange returns true if other is not nil and within radius of the entity.
+func (ctx *Context) inRange(other *entity.Entity, radius int32) bool {
+	return other != nil && ctx.World.Distance(ctx.Entity.Position.Current, other.Position.Current) <= float64(radius)
+}
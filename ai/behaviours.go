@@ -0,0 +1,277 @@
+package ai
+
+import (
+	"math/rand"
+
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// patrolAttempts is the number of random positions tried when looking for a new patrol target.
+const patrolAttempts = 10
+
+// Chase moves towards the target as long as the entity sees it within AI.AttackRange
+// and did not move further away than AI.AttackRangeUntil from its home.
+type Chase struct{}
+
+// Tick implements Node.
+func (n *Chase) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	if !ctx.inRange(ctx.Target, e.AI.AttackRange) || ctx.World.Distance(e.Position.Current, ctx.home()) > float64(e.AI.AttackRangeUntil) {
+		return StatusFailure
+	}
+	return ctx.moveAlong(ctx.Target.Position.Current)
+}
+
+// InvestigateLastSeen moves to the position where the entity last saw its target.
+// The entity gives up when it arrives there without seeing the target or when its memory fades.
+type InvestigateLastSeen struct{}
+
+// Tick implements Node.
+func (n *InvestigateLastSeen) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	if e.AI.LastKnownTargetPosition == nil {
+		return StatusFailure
+	}
+	path := ctx.World.Path(e, *e.AI.LastKnownTargetPosition)
+	if len(path) <= 1 {
+		ctx.forgetTarget()
+	}
+	if len(path) == 0 {
+		return StatusFailure
+	}
+	return ctx.moveTo(path[0])
+}
+
+// InvestigateNoise moves towards the last noise the entity heard.
+type InvestigateNoise struct{}
+
+// Tick implements Node.
+func (n *InvestigateNoise) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	if e.AI.NoiseTarget == nil {
+		return StatusFailure
+	}
+	path := ctx.World.Path(e, *e.AI.NoiseTarget)
+	if len(path) <= 1 {
+		// Arrived or not reachable, nothing more to investigate
+		e.AI.NoiseTarget = nil
+	}
+	if len(path) == 0 {
+		return StatusFailure
+	}
+	return ctx.moveTo(path[0])
+}
+
+// ReturnHome moves back to the spawn position. It fails when the entity is already there.
+type ReturnHome struct{}
+
+// Tick implements Node.
+func (n *ReturnHome) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	if e.Position.Current.Equal(e.Position.Initial) {
+		return StatusFailure
+	}
+	return ctx.moveAlong(e.Position.Initial)
+}
+
+// Wander takes a random step without leaving the Radius around its home.
+type Wander struct {
+	Radius int32
+}
+
+// Tick implements Node.
+func (n *Wander) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	home := ctx.home()
+	var candidates []utils.Vec2
+	for _, p := range ctx.World.Neighbors(e.Position.Current) {
+		if !p.Equal(e.Position.Current) && ctx.World.Walkable(p) && ctx.World.Distance(p, home) <= float64(n.Radius) {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return StatusFailure
+	}
+	return ctx.moveTo(candidates[rand.Intn(len(candidates))])
+}
+
+// Patrol walks between random positions within the Radius around the spawn position.
+type Patrol struct {
+	Radius int32
+}
+
+// Tick implements Node.
+func (n *Patrol) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	if e.AI.PatrolTarget == nil || e.AI.PatrolTarget.Equal(e.Position.Current) {
+		e.AI.PatrolTarget = nil
+		for i := 0; i < patrolAttempts; i++ {
+			p := e.Position.Initial.Add(utils.Vec2{X: rand.Int31n(2*n.Radius+1) - n.Radius, Y: rand.Int31n(2*n.Radius+1) - n.Radius})
+			if ctx.World.Walkable(p) {
+				e.AI.PatrolTarget = &p
+				break
+			}
+		}
+		if e.AI.PatrolTarget == nil {
+			return StatusFailure
+		}
+	}
+	if ctx.moveAlong(*e.AI.PatrolTarget) == StatusFailure {
+		e.AI.PatrolTarget = nil
+		return StatusFailure
+	}
+	return StatusSuccess
+}
+
+// Flee runs away from entities the entity is afraid of when they come closer than Radius.
+type Flee struct {
+	Radius int32
+}
+
+// Tick implements Node.
+func (n *Flee) Tick(ctx *Context) Status {
+	if !ctx.inRange(ctx.Threat, n.Radius) {
+		return StatusFailure
+	}
+	return ctx.moveAway(ctx.Threat.Position.Current)
+}
+
+// FleeAtLowHP runs away from the target when it is closer than Radius
+// and the health of the entity dropped below the fraction Threshold of its maximum.
+type FleeAtLowHP struct {
+	Radius    int32
+	Threshold float64
+}
+
+// Tick implements Node.
+func (n *FleeAtLowHP) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	if e.Health == nil || e.Health.HP <= 0 || float64(e.Health.CurrentHP)/float64(e.Health.HP) >= n.Threshold {
+		return StatusFailure
+	}
+	if !ctx.inRange(ctx.Target, n.Radius) {
+		return StatusFailure
+	}
+	return ctx.moveAway(ctx.Target.Position.Current)
+}
+
+// KeepDistance stays Distance tiles away from the target while it is visible.
+type KeepDistance struct {
+	Distance int32
+}
+
+// Tick implements Node.
+func (n *KeepDistance) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	if ctx.Target == nil {
+		return StatusFailure
+	}
+	d := ctx.World.Distance(e.Position.Current, ctx.Target.Position.Current)
+	switch {
+	case d < float64(n.Distance):
+		if ctx.moveAway(ctx.Target.Position.Current) == StatusFailure {
+			// Cornered, hold the position
+			return ctx.moveTo(e.Position.Current)
+		}
+		return StatusSuccess
+	case d > float64(n.Distance+1):
+		return ctx.moveAlong(ctx.Target.Position.Current)
+	default:
+		return ctx.moveTo(e.Position.Current)
+	}
+}
+
+// GuardItem stays next to the closest item or mutagen within Radius of the spawn position.
+type GuardItem struct {
+	Radius int32
+}
+
+// Tick implements Node.
+func (n *GuardItem) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	var guarded *utils.Vec2
+	closest := float64(n.Radius)
+	for _, other := range ctx.World.Entities() {
+		if (other.Item == nil && other.Mutagen == nil) || other.Position == nil {
+			continue
+		}
+		if d := ctx.World.Distance(e.Position.Initial, other.Position.Current); d <= closest {
+			p := other.Position.Current
+			guarded = &p
+			closest = d
+		}
+	}
+	if guarded == nil {
+		return StatusFailure
+	}
+	if ctx.World.Distance(e.Position.Current, *guarded) <= 1.5 {
+		return ctx.moveTo(e.Position.Current)
+	}
+	return ctx.moveAlong(*guarded)
+}
+
+// CallAllies alerts all friendly entities within Radius when the entity has a target.
+// The allies start hunting the target. It always succeeds.
+type CallAllies struct {
+	Radius int32
+}
+
+// Tick implements Node.
+func (n *CallAllies) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	if ctx.Target == nil {
+		return StatusSuccess
+	}
+	for _, other := range ctx.World.Entities() {
+		if other == e || other.AI == nil || other.IsDead != nil || other.Position == nil || ctx.World.Relation(other, e) != components.RelationFriendly {
+			continue
+		}
+		if ctx.inRange(other, n.Radius) {
+			Alert(other, ctx.Target.Position.Current)
+		}
+	}
+	return StatusSuccess
+}
+
+// FollowLeader moves towards the leader of the entity when it is further away than Distance.
+type FollowLeader struct {
+	Distance int32
+}
+
+// Tick implements Node.
+func (n *FollowLeader) Tick(ctx *Context) Status {
+	leader := ctx.World.Leader(ctx.Entity)
+	if leader == nil || leader.Position == nil || ctx.inRange(leader, n.Distance) {
+		return StatusFailure
+	}
+	return ctx.moveAlong(leader.Position.Current)
+}
+
+// Obey carries out the stay and attack orders given to a recruited companion.
+// Following is left to FollowLeader.
+type Obey struct{}
+
+// Tick implements Node.
+func (n *Obey) Tick(ctx *Context) Status {
+	c := ctx.Entity.Companion
+	if c == nil || !c.Recruited {
+		return StatusFailure
+	}
+	switch c.Order {
+	case components.CompanionOrderStay:
+		return ctx.moveTo(ctx.Entity.Position.Current)
+	case components.CompanionOrderAttack:
+		target := ctx.combatantNear(*c.AttackTarget)
+		if target == nil {
+			// Target is dead or lost, back to following
+			c.Order = components.CompanionOrderFollow
+			c.AttackTarget = nil
+			return StatusFailure
+		}
+		p := target.Position.Current
+		c.AttackTarget = &p
+		return ctx.moveAlong(p)
+	}
+	return StatusFailure
+}
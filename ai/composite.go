@@ -0,0 +1,44 @@
+package ai
+
+// Selector ticks its children in order until one of them succeeds.
+type Selector struct {
+	Children []Node
+}
+
+// Tick implements Node.
+func (n *Selector) Tick(ctx *Context) Status {
+	for _, c := range n.Children {
+		if c.Tick(ctx) == StatusSuccess {
+			return StatusSuccess
+		}
+	}
+	return StatusFailure
+}
+
+// Sequence ticks its children in order until one of them fails.
+type Sequence struct {
+	Children []Node
+}
+
+// Tick implements Node.
+func (n *Sequence) Tick(ctx *Context) Status {
+	for _, c := range n.Children {
+		if c.Tick(ctx) == StatusFailure {
+			return StatusFailure
+		}
+	}
+	return StatusSuccess
+}
+
+// Inverter turns the success of its child into a failure and vice versa.
+type Inverter struct {
+	Child Node
+}
+
+// Tick implements Node.
+func (n *Inverter) Tick(ctx *Context) Status {
+	if n.Child.Tick(ctx) == StatusSuccess {
+		return StatusFailure
+	}
+	return StatusSuccess
+}
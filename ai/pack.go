@@ -0,0 +1,76 @@
+package ai
+
+import (
+	"github.com/torlenor/asciiventure/pathfinding"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// Surround attacks the target together with the other members of the pack.
+// Every member approaches a different free position next to the target instead of
+// queueing up behind the others. Entities without a pack simply chase the target.
+type Surround struct{}
+
+// Tick implements Node.
+func (n *Surround) Tick(ctx *Context) Status {
+	e := ctx.Entity
+	if e.AI.Pack == nil {
+		return (&Chase{}).Tick(ctx)
+	}
+	if ctx.World.Distance(e.Position.Current, ctx.home()) > float64(e.AI.AttackRangeUntil) {
+		return StatusFailure
+	}
+
+	var goal utils.Vec2
+	switch {
+	case ctx.inRange(ctx.Target, e.AI.AttackRange):
+		goal = ctx.Target.Position.Current
+		if ctx.World.Distance(e.Position.Current, goal) < 1.5 {
+			return ctx.moveTo(goal)
+		}
+	case e.AI.LastKnownTargetPosition != nil:
+		goal = *e.AI.LastKnownTargetPosition
+	default:
+		return StatusFailure
+	}
+
+	reservations := e.AI.Pack.Reservations
+	obstacles := pathfinding.WithReservations(ctx.World.Obstacles(), reservations, e)
+	if approach, ok := ctx.approachPosition(goal, reservations); ok {
+		reservations.Reserve(approach, e)
+		if approach.Equal(e.Position.Current) {
+			if ctx.Target == nil {
+				// Nobody where the target was last seen
+				ctx.forgetTarget()
+				return StatusFailure
+			}
+			return ctx.moveTo(approach)
+		}
+		goal = approach
+	}
+	path := pathfinding.DetermineAstarPath(ctx.World.Graph(e), obstacles, e.Position.Current, goal)
+	if len(path) == 0 {
+		return StatusFailure
+	}
+	reservations.Reserve(path[0], e)
+	return ctx.moveTo(path[0])
+}
+
+// approachPosition returns the free position next to goal closest to the entity which is not reserved by another member of its pack.
+// The second return value is false if there is no such position.
+func (ctx *Context) approachPosition(goal utils.Vec2, reservations *pathfinding.Reservations) (utils.Vec2, bool) {
+	e := ctx.Entity
+	var best utils.Vec2
+	found := false
+	var bestDistance float64
+	for _, p := range ctx.World.Neighbors(goal) {
+		if p.Equal(goal) || reservations.ReservedByOther(p, e) || !(p.Equal(e.Position.Current) || ctx.World.Walkable(p)) {
+			continue
+		}
+		if d := ctx.World.Distance(e.Position.Current, p); !found || d < bestDistance {
+			best = p
+			bestDistance = d
+			found = true
+		}
+	}
+	return best, found
+}
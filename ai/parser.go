@@ -0,0 +1,108 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// nodeData is the JSON representation of a behaviour tree node.
+// Only the parameters used by the node type have to be provided.
+type nodeData struct {
+	Type     string     `json:"Type"`
+	Children []nodeData `json:"Children"`
+
+	Radius    int32   `json:"Radius"`
+	Distance  int32   `json:"Distance"`
+	Threshold float64 `json:"Threshold"`
+}
+
+func buildNode(d nodeData) (Node, error) {
+	var children []Node
+	for _, c := range d.Children {
+		child, err := buildNode(c)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	switch strings.ToLower(d.Type) {
+	case "selector":
+		return &Selector{Children: children}, nil
+	case "sequence":
+		return &Sequence{Children: children}, nil
+	case "inverter":
+		if len(children) != 1 {
+			return nil, fmt.Errorf("Inverter needs exactly one child, got %d", len(children))
+		}
+		return &Inverter{Child: children[0]}, nil
+	case "chase":
+		return &Chase{}, nil
+	case "surround":
+		return &Surround{}, nil
+	case "investigatelastseen":
+		return &InvestigateLastSeen{}, nil
+	case "investigatenoise":
+		return &InvestigateNoise{}, nil
+	case "returnhome":
+		return &ReturnHome{}, nil
+	case "wander":
+		return &Wander{Radius: d.Radius}, nil
+	case "patrol":
+		return &Patrol{Radius: d.Radius}, nil
+	case "flee":
+		return &Flee{Radius: d.Radius}, nil
+	case "fleeatlowhp":
+		return &FleeAtLowHP{Radius: d.Radius, Threshold: d.Threshold}, nil
+	case "keepdistance":
+		return &KeepDistance{Distance: d.Distance}, nil
+	case "guarditem":
+		return &GuardItem{Radius: d.Radius}, nil
+	case "followleader":
+		return &FollowLeader{Distance: d.Distance}, nil
+	case "obey":
+		return &Obey{}, nil
+	case "callallies":
+		return &CallAllies{Radius: d.Radius}, nil
+	default:
+		return nil, fmt.Errorf("Unknown behaviour node type '%s'", d.Type)
+	}
+}
+
+// ParseBehaviour parses a JSON file describing a behaviour tree and returns its root node.
+func ParseBehaviour(filename string) (Node, error) {
+	file, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading behaviour JSON file %s: %s", filename, err)
+	}
+	data := nodeData{}
+	if err := json.Unmarshal(file, &data); err != nil {
+		return nil, fmt.Errorf("Error parsing behaviour JSON file %s: %s", filename, err)
+	}
+	node, err := buildNode(data)
+	if err != nil {
+		return nil, fmt.Errorf("Error in behaviour JSON file %s: %s", filename, err)
+	}
+	return node, nil
+}
+
+// LoadBehaviours parses all behaviour trees in the given directory.
+// The trees are referenced by their file name without extension.
+func LoadBehaviours(directory string) (map[string]Node, error) {
+	files, err := filepath.Glob(filepath.Join(directory, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	behaviours := make(map[string]Node)
+	for _, f := range files {
+		node, err := ParseBehaviour(f)
+		if err != nil {
+			return nil, err
+		}
+		behaviours[strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))] = node
+	}
+	return behaviours, nil
+}
@@ -0,0 +1,115 @@
+package ai
+
+import (
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	// defaultMemoryDuration is the number of turns an entity remembers where it last saw its target,
+	// if AI.MemoryDuration is not set.
+	defaultMemoryDuration = 10
+
+	// sneakVisionFactor is the fraction of the vision range up to which a sneaking entity is noticed.
+	sneakVisionFactor = 0.5
+)
+
+// Alert makes the entity hunt a target which it knows to be at p.
+func Alert(e *entity.Entity, p utils.Vec2) {
+	e.AI.Alertness = components.AlertnessHunting
+	e.AI.LastKnownTargetPosition = &p
+	e.AI.MemoryRemaining = e.AI.MemoryDuration
+	if e.AI.MemoryRemaining <= 0 {
+		e.AI.MemoryRemaining = defaultMemoryDuration
+	}
+}
+
+// BeginTurn prepares the state shared by the members of packs for a new turn.
+// It has to be called once per turn before the monsters decide what to do.
+func BeginTurn(entities []*entity.Entity) {
+	done := make(map[*components.Pack]bool)
+	for _, e := range entities {
+		if e.AI == nil || e.AI.Pack == nil || done[e.AI.Pack] {
+			continue
+		}
+		pack := e.AI.Pack
+		done[pack] = true
+		pack.Reservations.Clear()
+		if pack.LastKnownTargetPosition != nil {
+			pack.MemoryRemaining--
+			if pack.MemoryRemaining <= 0 {
+				pack.LastKnownTargetPosition = nil
+			}
+		}
+	}
+}
+
+// perceive looks for hostile and feared entities in the field of view of the entity,
+// updates its memory and derives its alertness from it.
+// Members of a pack share what they know about the position of the target.
+func perceive(ctx *Context) {
+	e := ctx.Entity
+	ctx.Target = ctx.nearestNoticed(components.RelationHostile)
+	ctx.Threat = ctx.nearestNoticed(components.RelationAfraid)
+	if ctx.Target != nil {
+		Alert(e, ctx.Target.Position.Current)
+		if pack := e.AI.Pack; pack != nil {
+			p := ctx.Target.Position.Current
+			pack.LastKnownTargetPosition = &p
+			pack.MemoryRemaining = e.AI.MemoryRemaining
+		}
+		return
+	}
+	if ctx.Threat != nil {
+		// Fleeing monsters are wary, but they do not hunt anyone
+		e.AI.Alertness = components.AlertnessSuspicious
+		return
+	}
+	if e.AI.LastKnownTargetPosition != nil {
+		e.AI.MemoryRemaining--
+		if e.AI.MemoryRemaining <= 0 {
+			e.AI.LastKnownTargetPosition = nil
+		}
+	}
+	if pack := e.AI.Pack; pack != nil && pack.LastKnownTargetPosition != nil {
+		p := *pack.LastKnownTargetPosition
+		e.AI.LastKnownTargetPosition = &p
+		e.AI.MemoryRemaining = pack.MemoryRemaining
+	}
+	if e.AI.LastKnownTargetPosition != nil || e.AI.NoiseTarget != nil {
+		e.AI.Alertness = components.AlertnessSuspicious
+	} else {
+		e.AI.Alertness = components.AlertnessUnaware
+	}
+}
+
+// nearestNoticed returns the closest entity noticed by the entity which it regards with the given relation.
+func (ctx *Context) nearestNoticed(relation components.Relation) *entity.Entity {
+	var nearest *entity.Entity
+	var nearestDistance float64
+	for _, other := range ctx.World.Entities() {
+		if other == ctx.Entity || other.Position == nil || other.IsDead != nil || ctx.World.Relation(ctx.Entity, other) != relation || !ctx.notices(other) {
+			continue
+		}
+		if d := ctx.World.Distance(ctx.Entity.Position.Current, other.Position.Current); nearest == nil || d < nearestDistance {
+			nearest = other
+			nearestDistance = d
+		}
+	}
+	return nearest
+}
+
+// notices returns true if the other entity is in the field of view of the entity.
+// A sneaking entity is only noticed within sneakVisionFactor of the vision range.
+func (ctx *Context) notices(other *entity.Entity) bool {
+	e := ctx.Entity
+	if !e.FoV.Visible(other.Position.Current) {
+		return false
+	}
+	if other.Stealth != nil && other.Stealth.Sneaking && e.Vision != nil &&
+		ctx.World.Distance(e.Position.Current, other.Position.Current) > float64(e.Vision.Range)*sneakVisionFactor {
+		return false
+	}
+	return true
+}
@@ -56,10 +56,11 @@ func createTextureFromFile(renderer *renderers.Renderer, imagePath string) (*sdl
 // image and description file.
 func NewGlyphTexture(renderer *renderers.Renderer, imagePath string, descriptionPath string) (*GlyphTexture, error) {
 	g := &GlyphTexture{}
-	jsonFile, err := os.Open("./assets/textures/ascii_ext_courier.json")
+	jsonFile, err := os.Open(descriptionPath)
 	if err != nil {
 		return g, fmt.Errorf("Unable to open description file for glyph texture: %s", err)
 	}
+	defer jsonFile.Close()
 	byteValue, err := ioutil.ReadAll(jsonFile)
 	if err != nil {
 		return g, fmt.Errorf("Unable to read description file for glyph texture: %s", err)
@@ -68,7 +69,7 @@ func NewGlyphTexture(renderer *renderers.Renderer, imagePath string, description
 	if err != nil {
 		return g, fmt.Errorf("Unable to parse description file for glyph texture: %s", err)
 	}
-	g.t, err = createTextureFromFile(renderer, "./assets/textures/ascii_ext_courier.png")
+	g.t, err = createTextureFromFile(renderer, imagePath)
 	if err != nil {
 		return g, fmt.Errorf("Unable to load image texture: %s", err)
 	}
@@ -0,0 +1,159 @@
+// Package camera decides which part of a map is shown in a view with a fixed number of cells.
+package camera
+
+import (
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// Mode is the way the camera follows its target.
+type Mode int
+
+// List of Modes.
+const (
+	// ModeCentered keeps the target in the center of the view.
+	ModeCentered Mode = iota
+	// ModeDeadzone only moves the camera when the target leaves the deadzone around the center of the view.
+	ModeDeadzone
+	// ModeFreeLook does not follow the target at all, the camera is only moved with Pan.
+	ModeFreeLook
+
+	numModes
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeCentered:
+		return "centered"
+	case ModeDeadzone:
+		return "deadzone"
+	case ModeFreeLook:
+		return "free look"
+	default:
+		return "unknown"
+	}
+}
+
+// Next returns the mode following m, wrapping around after the last one.
+func (m Mode) Next() Mode {
+	return (m + 1) % numModes
+}
+
+// Camera holds the map position shown in the center of a view.
+// It never shows more than necessary outside of the map bounds.
+type Camera struct {
+	Mode Mode
+	// DeadzoneX and DeadzoneY are the number of tiles the target may move away from the center of the view
+	// in ModeDeadzone before the camera follows it.
+	DeadzoneX int32
+	DeadzoneY int32
+
+	viewWidth  int32
+	viewHeight int32
+	mapWidth   int32
+	mapHeight  int32
+
+	// goal is the position the camera moves to, position the one currently shown in the center of the view
+	goal     utils.Vec2
+	position utils.Vec2
+}
+
+// New returns a camera in the given mode with a view and map of size zero.
+func New(mode Mode, deadzoneX, deadzoneY int32) *Camera {
+	return &Camera{Mode: mode, DeadzoneX: deadzoneX, DeadzoneY: deadzoneY}
+}
+
+// SetView sets the number of cells of the view.
+func (c *Camera) SetView(w, h int32) {
+	c.viewWidth = w
+	c.viewHeight = h
+	c.goal = c.clamp(c.goal)
+}
+
+// SetBounds sets the number of tiles of the map.
+func (c *Camera) SetBounds(w, h int32) {
+	c.mapWidth = w
+	c.mapHeight = h
+	c.goal = c.clamp(c.goal)
+}
+
+// Follow lets the camera move to the target according to its mode.
+func (c *Camera) Follow(target utils.Vec2) {
+	switch c.Mode {
+	case ModeCentered:
+		c.goal = target
+	case ModeDeadzone:
+		c.goal.X = follow(c.goal.X, target.X, c.DeadzoneX)
+		c.goal.Y = follow(c.goal.Y, target.Y, c.DeadzoneY)
+	case ModeFreeLook:
+		return
+	}
+	c.goal = c.clamp(c.goal)
+}
+
+// follow returns the new center on one axis so that target is at most deadzone tiles away from it.
+func follow(center, target, deadzone int32) int32 {
+	if target-center > deadzone {
+		return target - deadzone
+	}
+	if center-target > deadzone {
+		return target + deadzone
+	}
+	return center
+}
+
+// Pan moves the camera by the given number of tiles and switches it to ModeFreeLook.
+func (c *Camera) Pan(dx, dy int32) {
+	c.Mode = ModeFreeLook
+	c.goal = c.clamp(utils.Vec2{X: c.goal.X + dx, Y: c.goal.Y + dy})
+}
+
+// Update moves the camera one step closer to its goal. It has to be called once per frame.
+// Every step covers half of the remaining distance, but at least one tile.
+func (c *Camera) Update() {
+	c.position.X = approach(c.position.X, c.goal.X)
+	c.position.Y = approach(c.position.Y, c.goal.Y)
+}
+
+func approach(current, goal int32) int32 {
+	d := goal - current
+	switch {
+	case d > 0:
+		return current + (d+1)/2
+	case d < 0:
+		return current - (1-d)/2
+	default:
+		return current
+	}
+}
+
+// Snap moves the camera to its goal immediately.
+func (c *Camera) Snap() {
+	c.position = c.goal
+}
+
+// Offset returns the offset to add to a map position to get the cell of the view it is shown in.
+func (c *Camera) Offset() (x, y int32) {
+	return c.viewWidth/2 - c.position.X, c.viewHeight/2 - c.position.Y
+}
+
+// clamp returns the center closest to p which shows no cells outside of the map,
+// or centers the map on the axes where it is smaller than the view.
+func (c *Camera) clamp(p utils.Vec2) utils.Vec2 {
+	return utils.Vec2{
+		X: clampAxis(p.X, c.viewWidth, c.mapWidth),
+		Y: clampAxis(p.Y, c.viewHeight, c.mapHeight),
+	}
+}
+
+func clampAxis(center, view, size int32) int32 {
+	if size <= view {
+		return view/2 - (view-size)/2
+	}
+	if min := view / 2; center < min {
+		return min
+	}
+	if max := size - view + view/2; center > max {
+		return max
+	}
+	return center
+}
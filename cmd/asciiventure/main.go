@@ -2,21 +2,62 @@ package main
 
 import (
 	"flag"
+	"log"
+	"os"
 
 	"github.com/torlenor/asciiventure/game"
 )
 
+// terminalLogFile receives the log output in terminal mode, where it would otherwise garble the screen.
+const terminalLogFile = "asciiventure.log"
+
 func main() {
 	var (
 		windowWidth  = flag.Int("w", 1024, "Window width to use")
 		windowHeight = flag.Int("h", 768, "Window height to use")
 		f            = flag.Bool("f", false, "Start in fullscreen mode")
+		backend      = flag.String("backend", game.BackendSDL, "Backend to use ("+game.BackendSDL+" or "+game.BackendTerminal+")")
+		snapshot     = flag.String("snapshot", "", "Render the game without a window into the given PNG file and exit")
+		seed         = flag.Int64("seed", 0, "Seed for the random number generator (0 uses the current time)")
+		turns        = flag.Int("turns", 0, "Number of turns to play before taking the snapshot")
 	)
 
 	flag.Parse()
 
+	if *backend == game.BackendFramebuffer && *snapshot == "" {
+		// The framebuffer backend has no input and would run forever
+		log.Fatalf("The %s backend can only be used with -snapshot", game.BackendFramebuffer)
+	}
+
+	if *backend == game.BackendTerminal {
+		logFile, err := os.Create(terminalLogFile)
+		if err != nil {
+			log.Fatalf("Unable to create log file: %s", err)
+		}
+		defer logFile.Close()
+		log.SetOutput(logFile)
+	}
+
+	if *snapshot != "" {
+		*backend = game.BackendFramebuffer
+	}
+
 	game := &game.Game{}
-	game.Setup(*windowWidth, *windowHeight, *f)
+	game.SetSeed(*seed)
+	if err := game.Setup(*backend, *windowWidth, *windowHeight, *f); err != nil {
+		// Restore the terminal before exiting, the log output goes to stderr again
+		game.Shutdown()
+		log.SetOutput(os.Stderr)
+		log.Fatalf("Unable to start the game: %s", err)
+	}
+	if *snapshot != "" {
+		err := game.Snapshot(*snapshot, *turns)
+		game.Shutdown()
+		if err != nil {
+			log.Fatalf("Unable to take snapshot: %s", err)
+		}
+		return
+	}
 	game.GameLoop()
 	game.Shutdown()
 }
@@ -4,14 +4,13 @@ import (
 	"flag"
 	"log"
 	"math/rand"
-	"runtime"
 	"strings"
 	"time"
 
 	"github.com/torlenor/asciiventure/console"
 	"github.com/torlenor/asciiventure/renderers"
+	"github.com/torlenor/asciiventure/tileset"
 	"github.com/torlenor/asciiventure/utils"
-	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
 )
 
@@ -27,34 +26,20 @@ func main() {
 		log.Fatalf("Failed to initialize ttf: %s", err)
 	}
 
-	err = sdl.Init(sdl.INIT_VIDEO)
+	backend, err := renderers.NewSDLBackend("Console Test", *windowWidth, *windowHeight, false)
 	if err != nil {
-		log.Fatalf("Failed to initialize sdl: %s", err)
+		log.Fatalf("Failed to create backend: %s", err)
 	}
+	myRenderer := backend.Renderer()
 
-	window, err := sdl.CreateWindow("Console Test", sdl.WINDOWPOS_CENTERED,
-		sdl.WINDOWPOS_CENTERED, int32(*windowWidth), int32(*windowHeight), sdl.WINDOW_SHOWN)
+	// tileset := "./assets/textures/terminal10x10_gs_tc.png"
+	// tileset := "./assets/textures/symbols64x64.png"
+	r, err := backend.GlyphRenderer(tileset.Config{Format: tileset.FormatLibtcod, Path: "./assets/textures/courier12x12_aa_tc.png"})
 	if err != nil {
-		log.Fatalf("Failed to create window: %s", err)
+		log.Fatalf("Failed to load tileset: %s", err)
 	}
-
-	sdl.SetHint(sdl.HINT_RENDER_SCALE_QUALITY, "1")
-
-	if runtime.GOOS == "windows" {
-		sdl.SetHint(sdl.HINT_RENDER_DRIVER, "opengl")
-	}
-
-	renderer, err := sdl.CreateRenderer(window, -1, sdl.RENDERER_ACCELERATED)
-	if err != nil {
-		log.Fatalf("Failed to create renderer: %s", err)
-	}
-
-	myRenderer := renderers.NewRenderer(renderer)
-
-	// tileset := console.NewFontTileset(myRenderer, "./assets/textures/terminal10x10_gs_tc.png")
-	// tileset := console.NewFontTileset(myRenderer, "./assets/textures/symbols64x64.png")
-	tileset, _ := console.NewFontTileset(myRenderer, "./assets/textures/courier12x12_aa_tc.png")
-	mconsole := console.NewMatrixConsole(myRenderer, tileset, int32(tileset.GetCharWidth()*80), int32(tileset.GetCharHeight()*50), 80, 50)
+	charWidth, charHeight := r.CellSize()
+	mconsole := console.NewMatrixConsole(r, charWidth*80, charHeight*50, 80, 50)
 
 	mconsole.SetOffset(100, 100)
 
@@ -80,9 +65,9 @@ func main() {
 		mconsole.Border(utils.ColorRGBA{R: 255, A: 255}, utils.ColorRGBA{})
 
 		myRenderer.SetDrawColor(255, 255, 255, 255)
-		myRenderer.Clear()
+		backend.Clear()
 		mconsole.Render()
-		myRenderer.Present()
+		backend.Present()
 		<-ticker.C
 	}
 
@@ -1,5 +1,7 @@
 package components
 
+import "github.com/torlenor/asciiventure/utils"
+
 // ActionType holds the type of the action to trigger.
 type ActionType int
 
@@ -11,14 +13,20 @@ const (
 	ActionTypeInteract
 	ActionTypeDropItem
 	ActionTypeUseItem
+	// ActionTypeUseAbility activates the activatable mutation in the ability slot stored in IntValue
+	ActionTypeUseAbility
+	// ActionTypeSearch searches the surroundings for hidden traps
+	ActionTypeSearch
 )
 
 func (d ActionType) String() string {
-	return [...]string{"None", "Move", "Interact", "Drop"}[d]
+	return [...]string{"None", "Move", "Interact", "Drop", "UseItem", "UseAbility", "Search"}[d]
 }
 
 // Actor component tells the systems what action shall be taken next
 type Actor struct {
 	NextAction ActionType
 	IntValue   int
+	// Target is the map position the action is aimed at, if it needs one
+	Target utils.Vec2
 }
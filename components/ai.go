@@ -1,7 +1,30 @@
 package components
 
+import "github.com/torlenor/asciiventure/utils"
+
 // The AI component holds information which influences the AI system for a given entity.
 type AI struct {
+	// Behaviour is the name of the behaviour tree deciding what the entity does
+	Behaviour string `json:"Behaviour"`
+
 	AttackRange      int32 `json:"AttackRange"`
 	AttackRangeUntil int32 `json:"AttackRangeUntil"`
+	// PackSize is the maximum number of monsters spawned together as a pack
+	PackSize int32 `json:"PackSize"`
+	// MemoryDuration is the number of turns the entity remembers where it last saw its target
+	MemoryDuration int32 `json:"MemoryDuration"`
+
+	// LastKnownTargetPosition is where the entity last saw its target
+	LastKnownTargetPosition *utils.Vec2 `json:"-"`
+	MemoryRemaining         int32       `json:"-"`
+
+	Alertness Alertness `json:"-"`
+
+	// NoiseTarget is the position of the last noise the entity heard and wants to investigate
+	NoiseTarget *utils.Vec2 `json:"-"`
+	// PatrolTarget is the position the entity is currently patrolling to
+	PatrolTarget *utils.Vec2 `json:"-"`
+
+	// Pack is shared with the other monsters the entity hunts together with, if any
+	Pack *Pack `json:"-"`
 }
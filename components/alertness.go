@@ -0,0 +1,16 @@
+package components
+
+// Alertness describes how aware a monster is of the player.
+type Alertness int
+
+// List of Alertness states.
+const (
+	AlertnessUnaware Alertness = iota
+	// AlertnessSuspicious monsters heard something or lost track of the player
+	AlertnessSuspicious
+	AlertnessHunting
+)
+
+func (d Alertness) String() string {
+	return [...]string{"Unaware", "Suspicious", "Hunting"}[d]
+}
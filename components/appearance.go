@@ -4,6 +4,8 @@ import "github.com/torlenor/asciiventure/utils"
 
 // Appearance holds all data related to the visual appearance of an entity.
 type Appearance struct {
-	Char  string          `json:"Char"`
-	Color utils.ColorRGBA `json:"Color"`
+	Char string `json:"Char"`
+	// ColorName is the name of the color in the palette. Color is used if it is empty or not defined in the palette.
+	ColorName string          `json:"ColorName,omitempty"`
+	Color     utils.ColorRGBA `json:"Color"`
 }
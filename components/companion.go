@@ -0,0 +1,26 @@
+package components
+
+import "github.com/torlenor/asciiventure/utils"
+
+// CompanionOrder is the order the player gave to a companion.
+type CompanionOrder int
+
+// List of CompanionOrders.
+const (
+	CompanionOrderFollow CompanionOrder = iota
+	CompanionOrderStay
+	CompanionOrderAttack
+)
+
+func (d CompanionOrder) String() string {
+	return [...]string{"Follow", "Stay", "Attack"}[d]
+}
+
+// Companion marks an entity which can join the player and fight alongside it.
+type Companion struct {
+	// Recruited is true when the companion has joined the player
+	Recruited bool           `json:"-"`
+	Order     CompanionOrder `json:"-"`
+	// AttackTarget is the last known position of the entity the companion was ordered to attack
+	AttackTarget *utils.Vec2 `json:"-"`
+}
@@ -0,0 +1,8 @@
+package components
+
+// Energy holds the mutagenic energy of an entity which is needed to activate mutations.
+type Energy struct {
+	Max          int32 `json:"Max"`
+	Current      int32 `json:"Current"`
+	Regeneration int32 `json:"Regeneration"`
+}
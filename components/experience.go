@@ -0,0 +1,27 @@
+package components
+
+// xpPerLevel scales the experience needed for the next level.
+const xpPerLevel = 20
+
+// Experience holds the experience of an entity and what it is worth when it is killed.
+type Experience struct {
+	Level   int32 `json:"Level"`
+	Current int32 `json:"Current"`
+	// Reward is the experience the killer of the entity gains
+	Reward int32 `json:"Reward"`
+}
+
+// NextLevel returns the total experience needed to reach the next level.
+func (x *Experience) NextLevel() int32 {
+	return xpPerLevel * x.Level * x.Level
+}
+
+// Add adds xp to the experience and returns the number of levels gained.
+func (x *Experience) Add(xp int32) (levels int32) {
+	x.Current += xp
+	for x.Current >= x.NextLevel() {
+		x.Level++
+		levels++
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Faction holds the name of the faction an entity belongs to.
+type Faction struct {
+	Name string `json:"Name"`
+}
+
+// Relation describes how a faction regards another faction.
+type Relation int
+
+// List of Relations.
+const (
+	RelationNeutral Relation = iota
+	RelationFriendly
+	RelationHostile
+	// RelationAfraid entities flee from the other faction
+	RelationAfraid
+)
+
+func (d Relation) String() string {
+	return [...]string{"Neutral", "Friendly", "Hostile", "Afraid"}[d]
+}
+
+// RelationFromString returns a Relation from the provided string.
+func RelationFromString(relationString string) (Relation, error) {
+	switch strings.ToLower(relationString) {
+	case "neutral":
+		return RelationNeutral, nil
+	case "friendly":
+		return RelationFriendly, nil
+	case "hostile":
+		return RelationHostile, nil
+	case "afraid":
+		return RelationAfraid, nil
+	default:
+		return RelationNeutral, fmt.Errorf("Unknown relation '%s'", relationString)
+	}
+}
+
+// UnmarshalJSON unmarshals a JSON into a Relation.
+func (d *Relation) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var ok bool
+	relationStr, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Relation not defined or not string")
+	}
+
+	var err error
+	relation, err := RelationFromString(relationStr)
+	if err != nil {
+		return err
+	}
+
+	*d = relation
+
+	return nil
+}
+
+// FactionTable holds for every faction how it regards the other factions.
+type FactionTable map[string]map[string]Relation
+
+// Relation returns how faction a regards faction b.
+// Members of the same faction are friendly and everything not listed is neutral.
+func (t FactionTable) Relation(a, b string) Relation {
+	if r, ok := t[a][b]; ok {
+		return r
+	}
+	if a == b {
+		return RelationFriendly
+	}
+	return RelationNeutral
+}
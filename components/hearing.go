@@ -0,0 +1,7 @@
+package components
+
+// Hearing holds all data related to hearing of an entity.
+type Hearing struct {
+	// Threshold is the lowest perceived volume of a noise the entity notices
+	Threshold int32 `json:"Threshold"`
+}
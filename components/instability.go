@@ -0,0 +1,18 @@
+package components
+
+// InstabilityThreshold is the amount of instability after which a random mutation event happens.
+const InstabilityThreshold = 10
+
+// Instability holds the genetic instability an entity accumulated by consuming mutagens.
+type Instability struct {
+	Current int32
+	// HallucinationTurns is the number of turns the entity still sees things which are not there
+	HallucinationTurns int32
+}
+
+// Add increases the instability by v and returns how many multiples of InstabilityThreshold have been crossed.
+func (i *Instability) Add(v int32) int {
+	before := i.Current / InstabilityThreshold
+	i.Current += v
+	return int(i.Current/InstabilityThreshold - before)
+}
@@ -0,0 +1,23 @@
+package components
+
+import "github.com/torlenor/asciiventure/utils"
+
+// KnownTraps holds the positions of the traps an entity triggered or saw being triggered.
+type KnownTraps struct {
+	Positions map[utils.Vec2]bool
+}
+
+// NewKnownTraps returns KnownTraps without any traps.
+func NewKnownTraps() *KnownTraps {
+	return &KnownTraps{Positions: make(map[utils.Vec2]bool)}
+}
+
+// Learn remembers the trap at p.
+func (k *KnownTraps) Learn(p utils.Vec2) {
+	k.Positions[p] = true
+}
+
+// Knows returns true if the entity knows about a trap at p.
+func (k *KnownTraps) Knows(p utils.Vec2) bool {
+	return k.Positions[p]
+}
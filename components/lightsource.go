@@ -0,0 +1,7 @@
+package components
+
+// LightSource makes an entity emit light around its position.
+type LightSource struct {
+	Radius    int32   `json:"Radius"`
+	Intensity float64 `json:"Intensity"`
+}
@@ -9,6 +9,18 @@ type Mutation struct {
 	Effect   MutationEffect   `json:"Effect"`
 	Category MutationCategory `json:"Category"`
 	Data     int32            `json:"Data"`
+
+	// Activatable mutations have to be triggered by the user, all others are always in effect
+	Activatable bool  `json:"Activatable"`
+	Cooldown    int32 `json:"Cooldown"`
+	EnergyCost  int32 `json:"EnergyCost"`
+
+	// CooldownRemaining is the number of turns until an activatable mutation can be used again
+	CooldownRemaining int32 `json:"-"`
+
+	// Instability is added to the instability of the entity consuming the mutagen
+	Instability int32                `json:"Instability"`
+	SideEffects []MutationSideEffect `json:"SideEffects"`
 }
 
 func (m Mutation) String() string {
@@ -36,6 +48,40 @@ func (m Mutations) Has(mutation MutationEffect) bool {
 	return false
 }
 
+// InCategory returns the indices of all mutations of the specified category.
+func (m Mutations) InCategory(category MutationCategory) (indices []int) {
+	for i, m := range m {
+		if m.IsCategory(category) {
+			indices = append(indices, i)
+		}
+	}
+	return
+}
+
+// Abilities returns the indices of all activatable mutations in the order they were gained.
+func (m Mutations) Abilities() (indices []int) {
+	for i, m := range m {
+		if m.Activatable {
+			indices = append(indices, i)
+		}
+	}
+	return
+}
+
+// SideEffects returns all side effects the mutations can cause, without duplicates.
+func (m Mutations) SideEffects() (sideEffects []MutationSideEffect) {
+	known := make(map[MutationSideEffect]bool)
+	for _, m := range m {
+		for _, s := range m.SideEffects {
+			if !known[s] {
+				known[s] = true
+				sideEffects = append(sideEffects, s)
+			}
+		}
+	}
+	return
+}
+
 // GetData returns the data for the specified MutationEffect, or always 0 if it does not exist.
 // Do not forget to check first with Has(mutation)!
 func (m Mutations) GetData(mutation MutationEffect) int32 {
@@ -66,6 +112,16 @@ func (m Mutation) GetDescription() string {
 		return fmt.Sprintf("Lets you look through walls.")
 	case MutationEffectIncreasedVision:
 		return fmt.Sprintf("Permanently increases vision by %d.", m.Data)
+	case MutationEffectHeightenedHearing:
+		return fmt.Sprintf("Lets you hear noises through walls from further away.")
+	case MutationEffectNightVision:
+		return fmt.Sprintf("Lets you see %d tiles further in the dark.", m.Data)
+	case MutationEffectPush:
+		return fmt.Sprintf("Pushes nearby enemies and items %d tiles away.", m.Data)
+	case MutationEffectTeleport:
+		return fmt.Sprintf("Teleports you to a random location within %d tiles.", m.Data)
+	case MutationEffectTeleportOther:
+		return fmt.Sprintf("Teleports the targeted enemy to a random location within %d tiles.", m.Data)
 	default:
 		return "Unknown"
 	}
@@ -18,10 +18,23 @@ const (
 	MutationCategoryTail
 )
 
+// MutationCategories lists all categories an entity can have mutations in.
+var MutationCategories = []MutationCategory{
+	MutationCategoryCore,
+	MutationCategoryEyes,
+	MutationCategoryClaws,
+	MutationCategoryTail,
+}
+
 func (d MutationCategory) String() string {
 	return [...]string{"Unknown", "Core", "Eyes", "Claws", "Tail"}[d]
 }
 
+// Slots returns how many mutations of that category an entity can have at the same time.
+func (d MutationCategory) Slots() int {
+	return [...]int{0, 3, 2, 2, 1}[d]
+}
+
 // MutationCategoryFromString returns a MutationCategory from the provided string.
 func MutationCategoryFromString(mutationCategoryString string) (MutationCategory, error) {
 	switch strings.ToLower(mutationCategoryString) {
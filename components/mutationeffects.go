@@ -14,11 +14,9 @@ const (
 	MutationEffectUnknown MutationEffect = iota
 	MutationEffectInventory
 	MutationEffectXRay
-	MutationEffectIncreasedVision // increase visibility range
-	// TODO: Think of a way to indicate other entities on the map without making them visible, e.g., "hear" enemies
+	MutationEffectIncreasedVision   // increase visibility range
 	MutationEffectHeightenedHearing // detect enemies even though you cannot see them
-	// TODO: Implement day/night system
-	MutationEffectNightVision // increased visibility range at night
+	MutationEffectNightVision       // increased visibility range at night
 	// TODO: Add a system which handles entity stats updates like healthregeneration in a turn
 	MutationEffectRegeneration // increased health/whatever regeneration
 	// TODO: Think of a way to influence enemy AI
@@ -49,6 +47,7 @@ func (d MutationEffect) String() string {
 		"Teleport",
 		"TeleportOther",
 		"BurrowingClaws",
+		"ForceField",
 	}[d]
 }
 
@@ -61,9 +60,9 @@ func MutationEffectFromString(mutationString string) (MutationEffect, error) {
 		return MutationEffectXRay, nil
 	case "increasedvision":
 		return MutationEffectIncreasedVision, nil
-	case "heightenedHearing":
+	case "heightenedhearing":
 		return MutationEffectHeightenedHearing, nil
-	case "nightVision":
+	case "nightvision":
 		return MutationEffectNightVision, nil
 	case "regeneration":
 		return MutationEffectRegeneration, nil
@@ -75,10 +74,12 @@ func MutationEffectFromString(mutationString string) (MutationEffect, error) {
 		return MutationEffectPush, nil
 	case "teleport":
 		return MutationEffectTeleport, nil
-	case "teleportOther":
+	case "teleportother":
 		return MutationEffectTeleportOther, nil
-	case "burrowingClaws":
+	case "burrowingclaws":
 		return MutationEffectBurrowingClaws, nil
+	case "forcefield":
+		return MutationEffectForceField, nil
 	default:
 		return MutationEffectUnknown, fmt.Errorf("Unknown mutation '%s'", mutationString)
 	}
@@ -0,0 +1,60 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MutationSideEffect type
+type MutationSideEffect int
+
+// Available MutationSideEffects
+const (
+	MutationSideEffectUnknown MutationSideEffect = iota
+	MutationSideEffectReducedHP
+	MutationSideEffectRandomTeleport
+	MutationSideEffectHallucination
+)
+
+func (d MutationSideEffect) String() string {
+	return [...]string{"Unknown", "ReducedHP", "RandomTeleport", "Hallucination"}[d]
+}
+
+// MutationSideEffectFromString returns a MutationSideEffect from the provided string.
+func MutationSideEffectFromString(sideEffectString string) (MutationSideEffect, error) {
+	switch strings.ToLower(sideEffectString) {
+	case "reducedhp":
+		return MutationSideEffectReducedHP, nil
+	case "randomteleport":
+		return MutationSideEffectRandomTeleport, nil
+	case "hallucination":
+		return MutationSideEffectHallucination, nil
+	default:
+		return MutationSideEffectUnknown, fmt.Errorf("Unknown mutation side effect '%s'", sideEffectString)
+	}
+}
+
+// UnmarshalJSON unmarshals a JSON into a MutationSideEffect.
+func (d *MutationSideEffect) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var ok bool
+	sideEffectStr, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("SideEffect not defined or not string")
+	}
+
+	var err error
+	sideEffect, err := MutationSideEffectFromString(sideEffectStr)
+	if err != nil {
+		return err
+	}
+
+	*d = sideEffect
+
+	return nil
+}
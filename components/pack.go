@@ -0,0 +1,21 @@
+package components
+
+import (
+	"github.com/torlenor/asciiventure/pathfinding"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// Pack holds the state shared by monsters hunting together. All members point to the same Pack.
+type Pack struct {
+	// LastKnownTargetPosition is where any member of the pack last saw the target
+	LastKnownTargetPosition *utils.Vec2
+	MemoryRemaining         int32
+
+	// Reservations are the positions the members claimed during the current turn
+	Reservations *pathfinding.Reservations
+}
+
+// NewPack returns a new Pack without members.
+func NewPack() *Pack {
+	return &Pack{Reservations: pathfinding.NewReservations()}
+}
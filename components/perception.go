@@ -0,0 +1,11 @@
+package components
+
+// Perception describes how good an entity is at noticing hidden things.
+type Perception struct {
+	Value int32 `json:"Value"`
+}
+
+// Stuck entities cannot move for a number of turns.
+type Stuck struct {
+	Turns int32
+}
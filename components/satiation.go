@@ -0,0 +1,46 @@
+package components
+
+// HungerState describes how hungry an entity is.
+type HungerState int
+
+// List of HungerStates.
+const (
+	HungerStateSatiated HungerState = iota
+	HungerStateNormal
+	HungerStateHungry
+	HungerStateStarving
+)
+
+func (d HungerState) String() string {
+	return [...]string{"Satiated", "Normal", "Hungry", "Starving"}[d]
+}
+
+// PowerModifier returns the change in combat power caused by the hunger state.
+func (d HungerState) PowerModifier() int32 {
+	return [...]int32{0, 0, -1, -2}[d]
+}
+
+// Satiation holds how well fed an entity is. It decreases every turn.
+type Satiation struct {
+	Current int32
+	Max     int32
+}
+
+// State returns the hunger state derived from the current satiation.
+func (s *Satiation) State() HungerState {
+	switch {
+	case s.Current > s.Max*3/4:
+		return HungerStateSatiated
+	case s.Current > s.Max/4:
+		return HungerStateNormal
+	case s.Current > s.Max/10:
+		return HungerStateHungry
+	default:
+		return HungerStateStarving
+	}
+}
+
+// Edible marks an entity whose corpse can be eaten.
+type Edible struct {
+	Nutrition int32 `json:"Nutrition"`
+}
@@ -0,0 +1,9 @@
+package components
+
+// Stealth holds the state of an entity which is able to sneak.
+type Stealth struct {
+	// Sneaking entities make less noise, but only move every second turn
+	Sneaking bool
+	// Waited is true if the sneaking entity waited in the previous turn and may move in this turn
+	Waited bool
+}
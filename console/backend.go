@@ -0,0 +1,80 @@
+package console
+
+import (
+	"image"
+
+	"github.com/torlenor/asciiventure/tileset"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// GlyphRenderer draws the cells of a MatrixConsole.
+// Coordinates are in screen units, which are pixels for graphical backends
+// and character cells for text based backends.
+type GlyphRenderer interface {
+	// CellSize returns the width and height of one cell in screen units.
+	CellSize() (w, h int32)
+	// PutGlyph draws char with the given colors into the cell with its upper left corner at x, y.
+	// An empty char only changes the background color of the cell.
+	PutGlyph(x, y int32, char string, foregroundColor utils.ColorRGBA, backgroundColor utils.ColorRGBA)
+}
+
+// Backend is the device the consoles are shown on and the input is read from,
+// for example a window or a terminal.
+type Backend interface {
+	// Size returns the width and height of the screen in screen units.
+	Size() (w, h int32)
+	// GlyphRenderer returns a GlyphRenderer using the tileset described by c, if the backend supports tilesets.
+	GlyphRenderer(c tileset.Config) (GlyphRenderer, error)
+
+	// Clear starts a new frame.
+	Clear()
+	// Present shows everything drawn since the last call to Clear.
+	Present()
+	// PollEvent returns the next pending input event or nil if there is none.
+	PollEvent() Event
+
+	// Close releases the backend. It must not be used afterwards.
+	Close()
+}
+
+// Screenshotter is implemented by backends which are able to capture what they show as an image.
+type Screenshotter interface {
+	// Screenshot returns the frame drawn since the last call to Clear. It has to be called before Present.
+	Screenshot() (image.Image, error)
+}
+
+// TilesetRenderer is implemented by backends which are able to draw glyphs from an already loaded tileset,
+// e.g., one combined from several others.
+type TilesetRenderer interface {
+	// TilesetGlyphRenderer returns a GlyphRenderer drawing glyphs from t.
+	TilesetGlyphRenderer(t *tileset.Tileset) (GlyphRenderer, error)
+}
+
+// MinimumSizer is implemented by backends whose screen can be resized by the user.
+type MinimumSizer interface {
+	// SetMinimumSize sets the size in screen units the screen cannot be made smaller than.
+	SetMinimumSize(w, h int32)
+}
+
+// Scaler is implemented by backends which are able to zoom the rendered output.
+type Scaler interface {
+	SetScale(scaleX, scaleY float32)
+}
+
+// CanvasRenderer is implemented by GlyphRenderers which are able to draw into an offscreen canvas.
+// A MatrixConsole uses it to redraw only the cells which changed since the last frame.
+type CanvasRenderer interface {
+	// NewCanvas returns an empty canvas with the given width and height in screen units.
+	NewCanvas(w, h int32) (Canvas, error)
+}
+
+// Canvas keeps glyphs drawn into it between frames.
+type Canvas interface {
+	// Update calls draw with a GlyphRenderer drawing into the canvas.
+	// In contrast to drawing onto the screen, every PutGlyph replaces the previous content of the cell.
+	Update(draw func(r GlyphRenderer))
+	// Draw copies the canvas onto the screen with its upper left corner at x, y.
+	Draw(x, y int32)
+	// Destroy releases the canvas. It must not be used afterwards.
+	Destroy()
+}
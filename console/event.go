@@ -0,0 +1,66 @@
+package console
+
+// Event is an input event read from a Backend.
+type Event interface{}
+
+// Keys with an ASCII code use it as their value.
+// Printable keys use their lowercase rune value, e.g., int('a').
+const (
+	KeyBackspace = 8
+	KeyTab       = 9
+	KeyReturn    = 13
+	KeyEscape    = 27
+	KeySpace     = 32
+)
+
+// Keys without a character are numbered beyond the range of unicode code points.
+const (
+	KeyUp = 0x110000 + iota
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+)
+
+// KeyEvent is sent when a key is pressed or released.
+type KeyEvent struct {
+	Key int
+
+	Shift bool
+	Ctrl  bool
+	Alt   bool
+
+	Pressed bool
+}
+
+// MouseEvent is sent when the mouse is moved or a button is pressed or released.
+// X and Y are in screen units and are -1 if the event does not carry a position.
+type MouseEvent struct {
+	X int32
+	Y int32
+
+	Left   bool
+	Middle bool
+	Right  bool
+}
+
+// ResizeEvent is sent when the size of the screen changed, e.g., because the window has been resized.
+// W and H are the new size in screen units.
+type ResizeEvent struct {
+	W int32
+	H int32
+}
+
+// QuitEvent is sent when the user wants to close the game, e.g., by closing the window.
+type QuitEvent struct{}
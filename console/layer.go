@@ -0,0 +1,15 @@
+package console
+
+// Layer is one of the z-ordered planes of a MatrixConsole. Cells of higher layers are drawn over the ones of lower layers.
+type Layer int
+
+// List of Layers from bottom to top.
+const (
+	LayerTerrain Layer = iota
+	LayerItems
+	LayerActors
+	LayerEffects
+	LayerOverlay
+
+	numLayers
+)
@@ -3,25 +3,29 @@ package console
 import (
 	"log"
 
-	"github.com/torlenor/asciiventure/renderers"
 	"github.com/torlenor/asciiventure/utils"
-	"github.com/veandco/go-sdl2/sdl"
 )
 
-// TileSet interface defines all the necessary functions for a Console
-// to retreive the glyphs for rendering.
-type TileSet interface {
-	Get(c string) (renderers.RenderGlyph, error)
-	GetCharWidth() int32
-	GetCharHeight() int32
+// cell is the content of one cell of a MatrixConsole.
+// The zero value is an empty cell which lets the layers below show through.
+type cell struct {
+	char            string
+	foregroundColor utils.ColorRGBA
+	backgroundColor utils.ColorRGBA
 }
 
 // MatrixConsole is used to render chars into fixed cells.
 // It provides convenient functions to render ASCII or any other
 // tiles onto a grid of specified size.
+//
+// The cells are organized in layers, see Layer. All drawing functions draw into
+// the layer selected with SetLayer. Render only redraws the cells which changed
+// since the last frame, if the GlyphRenderer supports a Canvas.
 type MatrixConsole struct {
-	renderer *renderers.Renderer
-	tileset  TileSet
+	renderer GlyphRenderer
+	canvas   Canvas
+	// noCanvas is true if the renderer does not support canvases or creating one failed
+	noCanvas bool
 
 	consoleWidth   int32
 	consoleHeight  int32
@@ -31,14 +35,50 @@ type MatrixConsole struct {
 	nx int32
 	ny int32
 
-	matrix map[int32]map[int32]renderers.RenderGlyph
+	// layer is the layer currently drawn into
+	layer  Layer
+	layers [numLayers][]cell
+
+	// composed holds the cells as they have been drawn in the last frame
+	composed []cell
+	// dirty marks the cells modified since the last frame, dirtyCells holds their indices
+	dirty      []bool
+	dirtyCells []int32
+	// changed holds the indices of the cells to redraw in the current frame
+	changed   []int32
+	redrawAll bool
 }
 
 // NewMatrixConsole returns a console with the given dimensions.
 // The dimensions nx x ny are number of cells, e.g., 80x50,
-// while consoleWidth x consoleHeight are the number of pixels for the console to use.
-func NewMatrixConsole(r *renderers.Renderer, tileset TileSet, w, h, nx, ny int32) *MatrixConsole {
-	return &MatrixConsole{consoleWidth: w, consoleHeight: h, nx: nx, ny: ny, tileset: tileset, renderer: r, matrix: make(map[int32]map[int32]renderers.RenderGlyph)}
+// while consoleWidth x consoleHeight are the number of screen units for the console to use.
+func NewMatrixConsole(r GlyphRenderer, w, h, nx, ny int32) *MatrixConsole {
+	c := &MatrixConsole{consoleWidth: w, consoleHeight: h, nx: nx, ny: ny, renderer: r, redrawAll: true}
+	for l := range c.layers {
+		c.layers[l] = make([]cell, nx*ny)
+	}
+	c.composed = make([]cell, nx*ny)
+	c.dirty = make([]bool, nx*ny)
+	return c
+}
+
+// Resize changes the dimensions of the console, see NewMatrixConsole. All cells are cleared.
+func (c *MatrixConsole) Resize(w, h, nx, ny int32) {
+	c.consoleWidth, c.consoleHeight = w, h
+	if nx != c.nx || ny != c.ny {
+		c.nx, c.ny = nx, ny
+		for l := range c.layers {
+			c.layers[l] = make([]cell, nx*ny)
+		}
+		c.composed = make([]cell, nx*ny)
+		c.dirty = make([]bool, nx*ny)
+		c.dirtyCells = c.dirtyCells[:0]
+		if c.canvas != nil {
+			c.canvas.Destroy()
+			c.canvas = nil
+		}
+	}
+	c.redrawAll = true
 }
 
 // GetDimensions returns the number of tiles in x and y direction of the console.
@@ -46,21 +86,35 @@ func (c *MatrixConsole) GetDimensions() (nx, ny int32) {
 	return c.nx, c.ny
 }
 
-// SetOffset shifts the console by the amount of pixels provided.
+// GetCellSize returns the size of one cell in screen units.
+func (c *MatrixConsole) GetCellSize() (w, h int32) {
+	return c.renderer.CellSize()
+}
+
+// SetOffset shifts the console by the amount of screen units provided.
 func (c *MatrixConsole) SetOffset(x, y int32) {
 	c.consoleOffsetX = x
 	c.consoleOffsetY = y
 }
 
-// GetOffset returns the currently set offset in pixels.
+// GetOffset returns the currently set offset in screen units.
 func (c *MatrixConsole) GetOffset() (x, y int32) {
 	return c.consoleOffsetX, c.consoleOffsetY
 }
 
+// SetLayer selects the layer all following drawing functions draw into.
+func (c *MatrixConsole) SetLayer(l Layer) {
+	c.layer = l
+}
+
+// Invalidate forces all cells to be redrawn by the next call to Render.
+func (c *MatrixConsole) Invalidate() {
+	c.redrawAll = true
+}
+
 // Render the console
 func (c *MatrixConsole) Render() {
-	charWidth := c.tileset.GetCharWidth()
-	charHeight := c.tileset.GetCharHeight()
+	charWidth, charHeight := c.renderer.CellSize()
 
 	var borderWidthHalf int32
 	var borderHeightHalf int32
@@ -74,103 +128,139 @@ func (c *MatrixConsole) Render() {
 	} else {
 		borderHeightHalf = (c.consoleHeight - charHeight*c.ny) / 2
 	}
+	x0 := c.consoleOffsetX + borderWidthHalf
+	y0 := c.consoleOffsetY + borderHeightHalf
 
-	cr, cg, cb, ca, _ := c.renderer.GetRenderer().GetDrawColor()
-	var bm sdl.BlendMode
-	c.renderer.GetRenderer().GetDrawBlendMode(&bm)
+	canvas := c.getCanvas(charWidth, charHeight)
+	c.compose()
 
-	for x, row := range c.matrix {
-		for y, g := range row {
-			dst := &sdl.Rect{
-				X: c.consoleOffsetX + borderWidthHalf + x*charWidth,
-				Y: c.consoleOffsetY + borderHeightHalf + y*charHeight,
-				W: charWidth, H: charHeight,
+	if canvas == nil {
+		for i, g := range c.composed {
+			if g == (cell{}) {
+				continue
 			}
-			// Render background
-			if g.BackgroundColor.A > 0 {
-				if g.Src != nil {
-					err := g.T.SetColorMod(1, 1, 1)
-					if err != nil {
-						log.Printf("Error setting Color in MatrixConsole Render: %s", err)
-					}
-					err = g.T.SetAlphaMod(255)
-					if err != nil {
-						log.Printf("Error setting Alpha in MatrixConsole Render: %s", err)
-					}
-				}
-
-				c.renderer.GetRenderer().SetDrawBlendMode(sdl.BLENDMODE_BLEND)
-				c.renderer.SetDrawColor(g.BackgroundColor.R, g.BackgroundColor.G, g.BackgroundColor.B, g.BackgroundColor.A)
-				c.renderer.GetRenderer().FillRect(dst)
+			x, y := int32(i)%c.nx, int32(i)/c.nx
+			c.renderer.PutGlyph(x0+x*charWidth, y0+y*charHeight, g.char, g.foregroundColor, g.backgroundColor)
+		}
+		return
+	}
+
+	if len(c.changed) > 0 {
+		canvas.Update(func(r GlyphRenderer) {
+			for _, i := range c.changed {
+				g := c.composed[i]
+				x, y := i%c.nx, i/c.nx
+				r.PutGlyph(x*charWidth, y*charHeight, g.char, g.foregroundColor, g.backgroundColor)
 			}
-			// Render foreground
-			if g.Src != nil {
-				err := g.T.SetColorMod(g.ForegroundColor.R, g.ForegroundColor.G, g.ForegroundColor.B)
-				if err != nil {
-					log.Printf("Error setting Color in MatrixConsole Render: %s", err)
-				}
-				err = g.T.SetAlphaMod(g.ForegroundColor.A)
-				if err != nil {
-					log.Printf("Error setting Alpha in MatrixConsole Render: %s", err)
-				}
-				err = c.renderer.Copy(g.T, g.Src, dst)
-				if err != nil {
-					log.Printf("Error in RenderWithOffset: %s", err)
-				}
+		})
+	}
+	canvas.Draw(x0, y0)
+}
+
+// getCanvas returns the canvas of the console, creating it if necessary.
+// It returns nil if the renderer does not support canvases.
+func (c *MatrixConsole) getCanvas(charWidth, charHeight int32) Canvas {
+	if c.canvas != nil || c.noCanvas {
+		return c.canvas
+	}
+	cr, ok := c.renderer.(CanvasRenderer)
+	if !ok {
+		c.noCanvas = true
+		return nil
+	}
+	canvas, err := cr.NewCanvas(c.nx*charWidth, c.ny*charHeight)
+	if err != nil {
+		log.Printf("Unable to create canvas, redrawing the whole console every frame: %s", err)
+		c.noCanvas = true
+		return nil
+	}
+	c.canvas = canvas
+	c.redrawAll = true
+	return c.canvas
+}
+
+// compose merges the layers of all dirty cells and collects the cells which look different than in the last frame.
+func (c *MatrixConsole) compose() {
+	c.changed = c.changed[:0]
+	if c.redrawAll {
+		for i := range c.composed {
+			c.composed[i] = c.composeCell(i)
+			c.changed = append(c.changed, int32(i))
+		}
+		c.redrawAll = false
+	} else {
+		for _, i := range c.dirtyCells {
+			if g := c.composeCell(int(i)); g != c.composed[i] {
+				c.composed[i] = g
+				c.changed = append(c.changed, i)
 			}
 		}
 	}
+	for _, i := range c.dirtyCells {
+		c.dirty[i] = false
+	}
+	c.dirtyCells = c.dirtyCells[:0]
+}
+
+// composeCell returns the cell at index i as seen from the top.
+// The char and background color are each taken from the highest layer which sets them.
+func (c *MatrixConsole) composeCell(i int) cell {
+	var g cell
+	for l := range c.layers {
+		lc := c.layers[l][i]
+		if len(lc.char) > 0 {
+			g.char = lc.char
+			g.foregroundColor = lc.foregroundColor
+		}
+		if lc.backgroundColor.A > 0 {
+			g.backgroundColor = lc.backgroundColor
+		}
+	}
+	return g
+}
 
-	c.renderer.SetDrawColor(cr, cg, cb, ca)
-	c.renderer.GetRenderer().SetDrawBlendMode(bm)
+// set replaces the cell at index i of layer l and marks it dirty if it changed.
+func (c *MatrixConsole) set(l Layer, i int32, g cell) {
+	if c.layers[l][i] == g {
+		return
+	}
+	c.layers[l][i] = g
+	if !c.dirty[i] {
+		c.dirty[i] = true
+		c.dirtyCells = append(c.dirtyCells, i)
+	}
+}
+
+func (c *MatrixConsole) inBounds(x, y int32) bool {
+	return x >= 0 && x < c.nx && y >= 0 && y < c.ny
 }
 
 // PutChar draws a character on the console using the default colors.
 // x: The x coordinate, the left-most position being 0.
 // y: The y coordinate, the top-most position being 0.
 func (c *MatrixConsole) PutChar(x, y int32, char string) {
-	if x < 0 || x >= c.nx || y < 0 || y >= c.ny {
-		return
-	}
-	if _, ok := c.matrix[x]; !ok {
-		c.matrix[x] = make(map[int32]renderers.RenderGlyph)
-	}
-	glyph, err := c.tileset.Get(char)
-	if err != nil {
-		log.Printf("Error getting glyph: %s", err)
-		return
-	}
-	c.matrix[x][y] = glyph
+	c.PutCharColor(x, y, char, utils.ColorRGBA{R: 255, G: 255, B: 255, A: 255}, utils.ColorRGBA{})
 }
 
 // PutCharColor draws a character on the console with the given colors.
 // x: The x coordinate, the left-most position being 0.
 // y: The y coordinate, the top-most position being 0.
 func (c *MatrixConsole) PutCharColor(x, y int32, char string, foregroundColor utils.ColorRGBA, backgroundColor utils.ColorRGBA) {
-	if x < 0 || x >= c.nx || y < 0 || y >= c.ny {
-		return
-	}
-	if _, ok := c.matrix[x]; !ok {
-		c.matrix[x] = make(map[int32]renderers.RenderGlyph)
-	}
-	glyph, err := c.tileset.Get(char)
-	if err != nil {
-		log.Printf("Error getting glyph: %s", err)
+	if !c.inBounds(x, y) {
 		return
 	}
-	glyph.ForegroundColor = foregroundColor
-	glyph.BackgroundColor = backgroundColor
-	c.matrix[x][y] = glyph
+	c.set(c.layer, y*c.nx+x, cell{char: char, foregroundColor: foregroundColor, backgroundColor: backgroundColor})
 }
 
 // SetBackgroundColor sets the background color of a tile to the provided value.
 func (c *MatrixConsole) SetBackgroundColor(x, y int32, backgroundColor utils.ColorRGBA) {
-	if _, ok := c.matrix[x]; !ok {
-		c.matrix[x] = make(map[int32]renderers.RenderGlyph)
+	if !c.inBounds(x, y) {
+		return
 	}
-	glyph := c.matrix[x][y]
-	glyph.BackgroundColor = backgroundColor
-	c.matrix[x][y] = glyph
+	i := y*c.nx + x
+	g := c.layers[c.layer][i]
+	g.backgroundColor = backgroundColor
+	c.set(c.layer, i, g)
 }
 
 // HLine draws a horizontal line with length l on the console with the default colors.
@@ -207,9 +297,21 @@ func (c *MatrixConsole) Border(foregroundColor utils.ColorRGBA, backgroundColor
 	c.PutCharColor(c.nx-1, c.ny-1, "┘", utils.ColorRGBA{R: 255, A: 255}, utils.ColorRGBA{})
 }
 
-// Clear the console.
+// Clear empties all layers of the console and selects LayerTerrain.
 func (c *MatrixConsole) Clear() {
-	c.matrix = make(map[int32]map[int32]renderers.RenderGlyph)
+	for l := range c.layers {
+		c.ClearLayer(Layer(l))
+	}
+	c.layer = LayerTerrain
+}
+
+// ClearLayer empties the given layer.
+func (c *MatrixConsole) ClearLayer(l Layer) {
+	for i, g := range c.layers[l] {
+		if g != (cell{}) {
+			c.set(l, int32(i), cell{})
+		}
+	}
 }
 
 func (c *MatrixConsole) outOfBounds(x, y int32) bool {
@@ -227,8 +329,7 @@ func (c *MatrixConsole) GetTileFromScreenCoordinates(mx, my int32) (x, y int32)
 		return -1, -1
 	}
 
-	charWidth := c.tileset.GetCharWidth()
-	charHeight := c.tileset.GetCharHeight()
+	charWidth, charHeight := c.renderer.CellSize()
 
 	x = int32(float32(mx-c.consoleOffsetX)+0.5) / charWidth
 	y = int32(float32(my-c.consoleOffsetY)+0.5) / charHeight
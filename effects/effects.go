@@ -0,0 +1,130 @@
+package effects
+
+import (
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// fade returns c with its alpha value scaled by f, which is clamped to the range from 0 to 1.
+func fade(c utils.ColorRGBA, f float64) utils.ColorRGBA {
+	if f < 0 {
+		f = 0
+	} else if f > 1 {
+		f = 1
+	}
+	c.A = uint8(float64(c.A) * f)
+	return c
+}
+
+// remaining returns the fraction of the duration left after the given frame.
+func remaining(frame, duration int32) float64 {
+	return 1 - float64(frame)/float64(duration)
+}
+
+// Flash colors the background of a map position and fades out.
+type Flash struct {
+	Position utils.Vec2
+	Color    utils.ColorRGBA
+	Duration int32
+}
+
+// Frames returns the duration of the flash.
+func (f *Flash) Frames() int32 {
+	return f.Duration
+}
+
+// Render draws the flash.
+func (f *Flash) Render(c *console.MatrixConsole, p Projection, frame int32) {
+	x, y := p.GetRenderCoordinatesFromPosition(f.Position.X, f.Position.Y)
+	c.SetBackgroundColor(x, y, fade(f.Color, remaining(frame, f.Duration)))
+}
+
+// trailLength is the number of positions behind a projectile which still show its trail.
+const trailLength = 3
+
+// Trail is a projectile moving along a path and leaving a fading trail behind.
+type Trail struct {
+	// Path holds the map positions the projectile passes, in order.
+	Path  []utils.Vec2
+	Char  string
+	Color utils.ColorRGBA
+	// FramesPerStep is the number of frames the projectile stays on one position.
+	FramesPerStep int32
+}
+
+// Frames returns the time the projectile and its trail need to pass the whole path.
+func (t *Trail) Frames() int32 {
+	return int32(len(t.Path)+trailLength) * t.FramesPerStep
+}
+
+// ImpactFrame returns the frame in which the projectile reaches the end of the path.
+func (t *Trail) ImpactFrame() int32 {
+	return int32(len(t.Path)-1) * t.FramesPerStep
+}
+
+// Render draws the projectile and its trail.
+func (t *Trail) Render(c *console.MatrixConsole, p Projection, frame int32) {
+	head := int(frame / t.FramesPerStep)
+	for i := 0; i <= trailLength; i++ {
+		n := head - i
+		if n < 0 || n >= len(t.Path) {
+			continue
+		}
+		x, y := p.GetRenderCoordinatesFromPosition(t.Path[n].X, t.Path[n].Y)
+		if i == 0 {
+			c.PutCharColor(x, y, t.Char, t.Color, utils.ColorRGBA{})
+		} else {
+			c.PutCharColor(x, y, "·", fade(t.Color, 1-float64(i)/float64(trailLength+1)), utils.ColorRGBA{})
+		}
+	}
+}
+
+// FloatingText is a short text, e.g., a damage number, rising from a map position and fading out.
+type FloatingText struct {
+	Position utils.Vec2
+	Text     string
+	Color    utils.ColorRGBA
+	// Rise is the number of cells the text moves up during its duration.
+	Rise     int32
+	Duration int32
+}
+
+// Frames returns the duration of the text.
+func (f *FloatingText) Frames() int32 {
+	return f.Duration
+}
+
+// Render draws the text centered above its position.
+// It only starts fading out in the last third of its duration.
+func (f *FloatingText) Render(c *console.MatrixConsole, p Projection, frame int32) {
+	x, y := p.GetRenderCoordinatesFromPosition(f.Position.X, f.Position.Y)
+	y -= 1 + f.Rise*frame/f.Duration
+	color := fade(f.Color, 3*remaining(frame, f.Duration))
+	runes := []rune(f.Text)
+	x -= int32(len(runes)) / 2
+	for i, r := range runes {
+		c.PutCharColor(x+int32(i), y, string(r), color, utils.ColorRGBA{})
+	}
+}
+
+// FadeIn lets map positions fade in, e.g., when they are seen for the first time.
+// It does not draw anything itself, the map queries the opacity of its positions from the Manager.
+type FadeIn struct {
+	Positions map[utils.Vec2]bool
+	Duration  int32
+}
+
+// Frames returns the duration of the fade in.
+func (f *FadeIn) Frames() int32 {
+	return f.Duration
+}
+
+// Render does nothing, see Manager.Opacity.
+func (f *FadeIn) Render(c *console.MatrixConsole, p Projection, frame int32) {}
+
+func (f *FadeIn) opacity(frame int32) float64 {
+	if frame < 0 {
+		return 0
+	}
+	return float64(frame+1) / float64(f.Duration)
+}
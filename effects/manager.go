@@ -0,0 +1,91 @@
+// Package effects provides timed visual effects, like damage flashes or projectiles, drawn onto the effects layer of a console.
+// All durations are given in frames of the game loop, which runs with FramesPerSecond.
+package effects
+
+import (
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// FramesPerSecond is the rate of the game loop advancing the effects.
+const FramesPerSecond = 15
+
+// Projection converts positions on the game map into cells of the console.
+type Projection interface {
+	GetRenderCoordinatesFromPosition(x, y int32) (int32, int32)
+}
+
+// Effect is a visual effect lasting a fixed number of frames.
+type Effect interface {
+	// Frames returns the number of frames the effect lasts.
+	Frames() int32
+	// Render draws the effect as it looks in the given frame, counting from 0.
+	Render(c *console.MatrixConsole, p Projection, frame int32)
+}
+
+type activeEffect struct {
+	effect Effect
+	// frame is the current frame of the effect, negative while it is delayed
+	frame int32
+}
+
+// Manager holds the running effects.
+type Manager struct {
+	effects []activeEffect
+}
+
+// Add starts the effect with the next frame.
+func (m *Manager) Add(e Effect) {
+	m.AddDelayed(e, 0)
+}
+
+// AddDelayed starts the effect after the given number of frames.
+func (m *Manager) AddDelayed(e Effect, delay int32) {
+	m.effects = append(m.effects, activeEffect{effect: e, frame: -delay})
+}
+
+// Clear stops all effects.
+func (m *Manager) Clear() {
+	m.effects = nil
+}
+
+// Running returns true if there are effects which have not finished yet.
+func (m *Manager) Running() bool {
+	return len(m.effects) > 0
+}
+
+// Advance moves all effects to their next frame and removes the finished ones.
+// It has to be called once per frame of the game loop.
+func (m *Manager) Advance() {
+	running := m.effects[:0]
+	for _, e := range m.effects {
+		e.frame++
+		if e.frame < e.effect.Frames() {
+			running = append(running, e)
+		}
+	}
+	m.effects = running
+}
+
+// Render draws the current frame of all started effects into the effects layer of the console.
+func (m *Manager) Render(c *console.MatrixConsole, p Projection) {
+	c.SetLayer(console.LayerEffects)
+	for _, e := range m.effects {
+		if e.frame >= 0 {
+			e.effect.Render(c, p, e.frame)
+		}
+	}
+}
+
+// Opacity returns how far the map position p has faded in, between 0 (invisible) and 1 (fully visible).
+func (m *Manager) Opacity(p utils.Vec2) float64 {
+	opacity := 1.0
+	for _, e := range m.effects {
+		if f, ok := e.effect.(*FadeIn); ok && f.Positions[p] {
+			if o := f.opacity(e.frame); o < opacity {
+				opacity = o
+			}
+		}
+	}
+	return opacity
+}
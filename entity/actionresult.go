@@ -13,10 +13,13 @@ const (
 	ActionResultItemUsed
 	ActionResultMutationConsumed
 	ActionResultMessage
+	ActionResultMutationSlotsFull
+	// ActionResultInstabilityEvent signals that a random mutation event shall happen
+	ActionResultInstabilityEvent
 )
 
 func (d ActionResultType) String() string {
-	return [...]string{"Unknown", "ItemPickedUp", "ItemDropped", "ItemUsed", "MutationConsumed", "Message"}[d]
+	return [...]string{"Unknown", "ItemPickedUp", "ItemDropped", "ItemUsed", "MutationConsumed", "Message", "MutationSlotsFull", "InstabilityEvent"}[d]
 }
 
 // ActionResult is the result of an action.
@@ -8,10 +8,12 @@ const (
 	CombatResultUnknown CombatResultType = iota
 	CombatResultTakeDamage
 	CombatResultMessage
+	// CombatResultSneakAttack signals that the attack hit an unaware target
+	CombatResultSneakAttack
 )
 
 func (d CombatResultType) String() string {
-	return [...]string{"Unknown", "Damage", "Message"}[d]
+	return [...]string{"Unknown", "Damage", "Message", "SneakAttack"}[d]
 }
 
 // CombatResult is one result of a combat action.
@@ -14,25 +14,38 @@ type Entity struct {
 	// TODO: Get rid of TargetPosition and move that into a component
 	TargetPosition utils.Vec2
 
-	Actor      *components.Actor
-	AI         *components.AI
-	Appearance *components.Appearance
-	Combat     *components.Combat
-	Health     *components.Health
-	IsBlocking *components.IsBlocking
-	IsDead     *components.IsDead
-	Item       *components.Item
-	Mutagen    *components.Mutation
-	Name       string // Every entity has a name, even when it's empty
-	Position   *components.Position
-	Vision     *components.Vision
+	Actor       *components.Actor
+	AI          *components.AI
+	Appearance  *components.Appearance
+	Combat      *components.Combat
+	Edible      *components.Edible
+	Companion   *components.Companion
+	Energy      *components.Energy
+	Experience  *components.Experience
+	Faction     *components.Faction
+	Health      *components.Health
+	Hearing     *components.Hearing
+	IsBlocking  *components.IsBlocking
+	IsDead      *components.IsDead
+	Instability *components.Instability
+	Item        *components.Item
+	KnownTraps  *components.KnownTraps
+	LightSource *components.LightSource
+	Mutagen     *components.Mutation
+	Name        string // Every entity has a name, even when it's empty
+	Perception  *components.Perception
+	Position    *components.Position
+	Satiation   *components.Satiation
+	Stealth     *components.Stealth
+	Stuck       *components.Stuck
+	Vision      *components.Vision
 
 	// TODO: Move FoV of the entity into the Vision component
 	FoV fov.FoVMap
 
 	Inventory *Inventory
 
-	// TODO: How many mutations are we allowed to have?
+	// Mutations are limited by the number of slots of their category
 	Mutations components.Mutations
 }
 
@@ -120,21 +133,54 @@ func (e *Entity) UseItem(target *Entity) (result []ActionResult) {
 }
 
 // ConsumeMutation takes the mutation defined in target and adds it to e.
+// If all slots of the mutation category are in use, nothing is consumed and
+// ActionResultMutationSlotsFull is returned, see ReplaceMutation.
 func (e *Entity) ConsumeMutation(target *Entity) (result []ActionResult) {
 	if target.Mutagen != nil {
-		if !e.Mutations.Has(target.Mutagen.Effect) {
+		category := target.Mutagen.Category
+		if e.Mutations.Has(target.Mutagen.Effect) {
+			result = append(result, ActionResult{Type: ActionResultMessage, StringValue: fmt.Sprintf("%s already has %s.", e.Name, target.Name)})
+		} else if len(e.Mutations.InCategory(category)) >= category.Slots() {
+			result = append(result, ActionResult{Type: ActionResultMutationSlotsFull, MutationEffectValue: target.Mutagen.Effect})
+			result = append(result, ActionResult{Type: ActionResultMessage, StringValue: fmt.Sprintf("%s has no free %s slot for %s.", e.Name, category, target.Name)})
+		} else {
 			e.Mutations = append(e.Mutations, *target.Mutagen)
 			target.Position = nil
 			result = append(result, ActionResult{Type: ActionResultMutationConsumed, MutationEffectValue: target.Mutagen.Effect})
 			result = append(result, ActionResult{Type: ActionResultMessage, StringValue: fmt.Sprintf("%s gained mutation %s.", e.Name, target.Name)})
-		} else {
-			result = append(result, ActionResult{Type: ActionResultMessage, StringValue: fmt.Sprintf("%s already has %s.", e.Name, target.Name)})
+			result = append(result, e.destabilize(target.Mutagen.Instability)...)
 		}
 	}
 
 	return
 }
 
+// ReplaceMutation replaces the mutation at index in the mutations of e with the mutation defined in target.
+func (e *Entity) ReplaceMutation(target *Entity, index int) (result []ActionResult) {
+	if target.Mutagen == nil || index < 0 || index >= len(e.Mutations) {
+		return
+	}
+	old := e.Mutations[index]
+	e.Mutations[index] = *target.Mutagen
+	target.Position = nil
+	result = append(result, ActionResult{Type: ActionResultMutationConsumed, MutationEffectValue: target.Mutagen.Effect})
+	result = append(result, ActionResult{Type: ActionResultMessage, StringValue: fmt.Sprintf("%s lost mutation %s and gained mutation %s.", e.Name, old.Effect, target.Name)})
+	result = append(result, e.destabilize(target.Mutagen.Instability)...)
+
+	return
+}
+
+// destabilize adds instability to e and returns an ActionResultInstabilityEvent for every crossed threshold.
+func (e *Entity) destabilize(instability int32) (result []ActionResult) {
+	if e.Instability == nil || instability == 0 {
+		return
+	}
+	for i := e.Instability.Add(instability); i > 0; i-- {
+		result = append(result, ActionResult{Type: ActionResultInstabilityEvent})
+	}
+	return
+}
+
 // MoveTo moves the entity to (y,y).
 func (e *Entity) MoveTo(p utils.Vec2) {
 	if e.Position != nil {
@@ -144,13 +190,33 @@ func (e *Entity) MoveTo(p utils.Vec2) {
 	}
 }
 
+// Eat lets the entity eat the corpse of target.
+func (e *Entity) Eat(target *Entity) (result []ActionResult) {
+	if e.Satiation == nil || target.IsDead == nil || target.Edible == nil {
+		return
+	}
+	e.Satiation.Current = utils.MinInt32(e.Satiation.Current+target.Edible.Nutrition, e.Satiation.Max)
+	target.Position = nil
+	result = append(result, ActionResult{Type: ActionResultMessage, StringValue: fmt.Sprintf("%s eats the %s.", e.Name, target.Name)})
+	return
+}
+
 // Attack the target entity.
 func (e *Entity) Attack(target *Entity) (results []CombatResult) {
 	if target.Combat == nil {
 		return
 	}
 
-	dmg := e.Combat.Power - target.Combat.Defense
+	power := e.Combat.Power
+	if e.Satiation != nil {
+		power += e.Satiation.State().PowerModifier()
+	}
+	dmg := power - target.Combat.Defense
+	if target.AI != nil && target.AI.Alertness == components.AlertnessUnaware {
+		// Unaware targets do not defend themselves
+		dmg += power
+		results = append(results, CombatResult{Type: CombatResultSneakAttack})
+	}
 	if dmg < 0 {
 		dmg = 0
 	}
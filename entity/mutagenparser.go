@@ -2,6 +2,14 @@ package entity
 
 import (
 	"log"
+
+	"github.com/torlenor/asciiventure/components"
+)
+
+// Mutagens glow faintly, unless their description has a LightSource.
+const (
+	mutagenLightRadius    = 2
+	mutagenLightIntensity = 0.5
 )
 
 // ParseMutagen parses a mutagen description and returns the corresponding entity.
@@ -16,6 +24,9 @@ func ParseMutagen(filename string) *Entity {
 		log.Printf("Not a mutagen entity file")
 		return nil
 	}
+	if e.LightSource == nil {
+		e.LightSource = &components.LightSource{Radius: mutagenLightRadius, Intensity: mutagenLightIntensity}
+	}
 
 	return e
 }
@@ -9,14 +9,20 @@ import (
 )
 
 type entityData struct {
-	Name       string                 `json:"Name"`
-	Appearance *components.Appearance `json:"Appearance"`
-	Health     *components.Health     `json:"Health"`
-	Combat     *components.Combat     `json:"Combat"`
-	AI         *components.AI         `json:"AI"`
-	Vision     *components.Vision     `json:"Vision"`
-	Item       *components.Item       `json:"Item"`
-	Mutagen    *components.Mutation   `json:"Mutagen"`
+	Name        string                  `json:"Name"`
+	Appearance  *components.Appearance  `json:"Appearance"`
+	Health      *components.Health      `json:"Health"`
+	Combat      *components.Combat      `json:"Combat"`
+	Edible      *components.Edible      `json:"Edible"`
+	Experience  *components.Experience  `json:"Experience"`
+	Companion   *components.Companion   `json:"Companion"`
+	Faction     *components.Faction     `json:"Faction"`
+	AI          *components.AI          `json:"AI"`
+	Vision      *components.Vision      `json:"Vision"`
+	Hearing     *components.Hearing     `json:"Hearing"`
+	LightSource *components.LightSource `json:"LightSource"`
+	Item        *components.Item        `json:"Item"`
+	Mutagen     *components.Mutation    `json:"Mutagen"`
 }
 
 // TODO: Make it possible to parse one JSON file containing many entity definitions.
@@ -36,10 +42,29 @@ func ParseJSON(filename string) (*Entity, error) {
 	e.Appearance = data.Appearance
 	e.Health = data.Health
 	e.Combat = data.Combat
+	e.Edible = data.Edible
+	e.Experience = data.Experience
+	e.Companion = data.Companion
+	e.Faction = data.Faction
 	e.AI = data.AI
 	e.Vision = data.Vision
+	e.Hearing = data.Hearing
+	e.LightSource = data.LightSource
 	e.Item = data.Item
 	e.Mutagen = data.Mutagen
 
 	return e, nil
 }
+
+// ParseFactions parses a JSON file describing the relations between factions.
+func ParseFactions(filename string) (components.FactionTable, error) {
+	file, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading factions JSON file %s: %s", filename, err)
+	}
+	table := components.FactionTable{}
+	if err := json.Unmarshal(file, &table); err != nil {
+		return nil, fmt.Errorf("Error parsing factions JSON file %s: %s", filename, err)
+	}
+	return table, nil
+}
@@ -0,0 +1,68 @@
+package fov
+
+import (
+	"math"
+
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// MinVisibleLight is the light level a position needs to be visible from further away than the dark vision range.
+const MinVisibleLight = 0.3
+
+// LightSource is a position emitting light.
+type LightSource struct {
+	Position  utils.Vec2
+	Radius    int32
+	Intensity float64
+}
+
+// LightMap holds the light level of the positions of a map between 0 (dark) and 1 (fully lit).
+type LightMap struct {
+	Ambient float64
+
+	levels map[int32]map[int32]float64
+}
+
+// NewLightMap returns a LightMap with the given ambient light level.
+func NewLightMap(ambient float64) *LightMap {
+	return &LightMap{Ambient: ambient, levels: make(map[int32]map[int32]float64)}
+}
+
+// Get returns the light level at p.
+func (m *LightMap) Get(p utils.Vec2) float64 {
+	if m == nil {
+		return 1
+	}
+	return math.Min(1, m.Ambient+m.levels[p.Y][p.X])
+}
+
+func (m *LightMap) add(p utils.Vec2, v float64) {
+	if _, ok := m.levels[p.Y]; !ok {
+		m.levels[p.Y] = make(map[int32]float64)
+	}
+	m.levels[p.Y][p.X] += v
+}
+
+// UpdateLightMap recalculates the light levels of all positions lit by the given sources.
+// Light falls off linearly with distance and does not pass opaque positions.
+func UpdateLightMap(r OpaqueGraph, lightMap *LightMap, sources []LightSource) {
+	lightMap.levels = make(map[int32]map[int32]float64)
+	lit := NewFovMap()
+	for _, s := range sources {
+		UpdateFoV(r, lit, s.Radius+1, s.Position, false)
+		for y, row := range lit {
+			for x, f := range row {
+				if !f.Visible {
+					continue
+				}
+				p := utils.Vec2{X: x, Y: y}
+				dx := float64(p.X - s.Position.X)
+				dy := float64(p.Y - s.Position.Y)
+				falloff := 1 - math.Sqrt(dx*dx+dy*dy)/float64(s.Radius+1)
+				if falloff > 0 {
+					lightMap.add(p, s.Intensity*falloff)
+				}
+			}
+		}
+	}
+}
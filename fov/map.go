@@ -62,3 +62,29 @@ func (m FoVMap) ClearVisible() {
 		}
 	}
 }
+
+// Copy returns an independent copy of the map.
+func (m FoVMap) Copy() FoVMap {
+	c := make(FoVMap, len(m))
+	for y, row := range m {
+		c[y] = make(map[int32]FoV, len(row))
+		for x, f := range row {
+			c[y][x] = f
+		}
+	}
+	return c
+}
+
+// NewlySeen returns the positions which are visible in m but have not been seen in previous.
+func (m FoVMap) NewlySeen(previous FoVMap) []utils.Vec2 {
+	var positions []utils.Vec2
+	for y, row := range m {
+		for x, f := range row {
+			p := utils.Vec2{X: x, Y: y}
+			if f.Visible && !previous.Seen(p) {
+				positions = append(positions, p)
+			}
+		}
+	}
+	return positions
+}
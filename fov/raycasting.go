@@ -16,24 +16,34 @@ type OpaqueGraph interface {
 // UpdateFoV updates the map with current field of view data based on the provided entity postion.
 // viewRange is the number of tiles the entity can see.
 func UpdateFoV(r OpaqueGraph, fovMap FoVMap, viewRange int32, entityPosition utils.Vec2, ignoreOpaque bool) {
+	UpdateFoVWithLight(r, fovMap, viewRange, entityPosition, ignoreOpaque, nil, viewRange)
+}
+
+// UpdateFoVWithLight updates the map with current field of view data based on the provided entity postion,
+// taking the light levels into account.
+// Positions further away than darkVisionRange are only visible if they are lit by at least MinVisibleLight.
+// A nil lightMap is treated as fully lit.
+func UpdateFoVWithLight(r OpaqueGraph, fovMap FoVMap, viewRange int32, entityPosition utils.Vec2, ignoreOpaque bool, lightMap *LightMap, darkVisionRange int32) {
 	fovMap.ClearVisible()
 	for i := 0; i < 360; i++ {
 		uvecX := math.Cos(float64(i) * 0.01745)
 		uvecY := math.Sin(float64(i) * 0.01745)
-		doFoV(r, fovMap, viewRange, uvecX, uvecY, entityPosition, ignoreOpaque)
+		doFoV(r, fovMap, viewRange, uvecX, uvecY, entityPosition, ignoreOpaque, lightMap, darkVisionRange)
 	}
 }
 
 // doFoV performs the actual Field of View calculation for the given view range and player coordinates
 // in the direction of the provided unit vector (x,y).
-func doFoV(r OpaqueGraph, fovMap FoVMap, viewRange int32, x float64, y float64, entityPosition utils.Vec2, ignoreOpaque bool) {
+func doFoV(r OpaqueGraph, fovMap FoVMap, viewRange int32, x float64, y float64, entityPosition utils.Vec2, ignoreOpaque bool, lightMap *LightMap, darkVisionRange int32) {
 	ox := float64(entityPosition.X)
 	oy := float64(entityPosition.Y)
 	for i := int32(0); i < viewRange; i++ {
 		ix := int32(ox + 0.5)
 		iy := int32(oy + 0.5)
-		fovMap.UpdateSeen(utils.Vec2{X: ix, Y: iy}, true)
-		fovMap.UpdateVisible(utils.Vec2{X: ix, Y: iy}, true)
+		if i <= darkVisionRange || lightMap.Get(utils.Vec2{X: ix, Y: iy}) >= MinVisibleLight {
+			fovMap.UpdateSeen(utils.Vec2{X: ix, Y: iy}, true)
+			fovMap.UpdateVisible(utils.Vec2{X: ix, Y: iy}, true)
+		}
 		if r.Opaque(utils.Vec2{X: ix, Y: iy}) && !ignoreOpaque {
 			return
 		}
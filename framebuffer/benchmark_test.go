@@ -0,0 +1,109 @@
+package framebuffer
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/tileset"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// fullRedraw hides the canvas support of a GlyphRenderer, so that the console redraws every cell each frame.
+type fullRedraw struct {
+	console.GlyphRenderer
+}
+
+var benchmarkTileset = tileset.Config{Format: tileset.FormatLibtcod, Path: "../assets/textures/consolas6x12_gs_tc.png"}
+
+var (
+	colorWall  = utils.ColorRGBA{R: 200, G: 200, B: 200, A: 255}
+	colorFloor = utils.ColorRGBA{R: 220, G: 220, B: 220, A: 100}
+	colorActor = utils.ColorRGBA{R: 255, G: 80, B: 80, A: 255}
+	colorMouse = utils.ColorRGBA{R: 128, G: 128, B: 128, A: 120}
+)
+
+// benchmarkScene draws a frame the way the game does: the whole console is cleared
+// and the terrain, the actors and an overlay are drawn again.
+type benchmarkScene struct {
+	nx, ny int32
+	actors []utils.Vec2
+}
+
+func newBenchmarkScene(nx, ny int32, actors int) *benchmarkScene {
+	s := &benchmarkScene{nx: nx, ny: ny}
+	for i := 0; i < actors; i++ {
+		s.actors = append(s.actors, utils.Vec2{X: rand.Int31n(nx), Y: rand.Int31n(ny)})
+	}
+	return s
+}
+
+// step moves every actor by one cell.
+func (s *benchmarkScene) step() {
+	for i, a := range s.actors {
+		s.actors[i] = utils.Vec2{X: (a.X + 1) % s.nx, Y: a.Y}
+	}
+}
+
+func (s *benchmarkScene) draw(c *console.MatrixConsole, frame int32) {
+	c.Clear()
+	c.SetLayer(console.LayerTerrain)
+	for y := int32(0); y < s.ny; y++ {
+		for x := int32(0); x < s.nx; x++ {
+			if (x+frame)%7 == 0 || (y+frame)%5 == 0 {
+				c.PutCharColor(x, y, "#", colorWall, utils.ColorRGBA{})
+			} else {
+				c.PutCharColor(x, y, "·", colorFloor, utils.ColorRGBA{})
+			}
+		}
+	}
+	c.SetLayer(console.LayerActors)
+	for _, a := range s.actors {
+		c.PutCharColor(a.X, a.Y, "o", colorActor, utils.ColorRGBA{})
+	}
+	c.SetLayer(console.LayerOverlay)
+	c.SetBackgroundColor(s.nx/2, s.ny/2, colorMouse)
+}
+
+// benchmarkRender renders frames of a scene with nx x ny cells and 30 moving actors.
+// If dirty is false every cell is redrawn each frame. If scroll is true the terrain moves every frame,
+// otherwise only the actors move.
+func benchmarkRender(b *testing.B, nx, ny int32, dirty, scroll bool) {
+	probe := New(1, 1)
+	r, err := probe.GlyphRenderer(benchmarkTileset)
+	if err != nil {
+		b.Fatalf("Failed to load tileset: %s", err)
+	}
+	cw, ch := r.CellSize()
+
+	f := New(nx*cw, ny*ch)
+	r, err = f.GlyphRenderer(benchmarkTileset)
+	if err != nil {
+		b.Fatalf("Failed to load tileset: %s", err)
+	}
+	if !dirty {
+		r = fullRedraw{r}
+	}
+	c := console.NewMatrixConsole(r, nx*cw, ny*ch, nx, ny)
+	s := newBenchmarkScene(nx, ny, 30)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		frame := int32(0)
+		if scroll {
+			frame = int32(i)
+		}
+		s.step()
+		s.draw(c, frame)
+		f.Clear()
+		c.Render()
+		f.Present()
+	}
+}
+
+func BenchmarkRender200x80(b *testing.B) {
+	b.Run("DirtyCells", func(b *testing.B) { benchmarkRender(b, 200, 80, true, false) })
+	b.Run("DirtyCellsScrolling", func(b *testing.B) { benchmarkRender(b, 200, 80, true, true) })
+	b.Run("FullRedraw", func(b *testing.B) { benchmarkRender(b, 200, 80, false, false) })
+	b.Run("FullRedrawScrolling", func(b *testing.B) { benchmarkRender(b, 200, 80, false, true) })
+}
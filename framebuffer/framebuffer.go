@@ -0,0 +1,224 @@
+// Package framebuffer implements a console backend which renders into an in-memory image without SDL,
+// e.g., to take screenshots on machines without a display.
+package framebuffer
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"log"
+
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/tileset"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+var clearColor = color.RGBA{A: 255}
+
+// Framebuffer is a console.Backend where one screen unit is one pixel of an image.
+// Input events can be queued with PushEvent.
+type Framebuffer struct {
+	// back is the frame currently drawn, front the last presented one
+	back  *image.RGBA
+	front *image.RGBA
+
+	glyphRenderers map[tileset.Config]*glyphRenderer
+
+	events []console.Event
+}
+
+// New returns a Framebuffer with the given width and height in pixels.
+func New(w, h int32) *Framebuffer {
+	return &Framebuffer{
+		back:           image.NewRGBA(image.Rect(0, 0, int(w), int(h))),
+		front:          image.NewRGBA(image.Rect(0, 0, int(w), int(h))),
+		glyphRenderers: make(map[tileset.Config]*glyphRenderer),
+	}
+}
+
+// Size returns the width and height of the framebuffer in pixels.
+func (f *Framebuffer) Size() (w, h int32) {
+	b := f.back.Bounds()
+	return int32(b.Dx()), int32(b.Dy())
+}
+
+// GlyphRenderer returns a console.GlyphRenderer drawing glyphs from the tileset described by c.
+func (f *Framebuffer) GlyphRenderer(c tileset.Config) (console.GlyphRenderer, error) {
+	if r, ok := f.glyphRenderers[c]; ok {
+		return r, nil
+	}
+	t, err := tileset.Load(c)
+	if err != nil {
+		return nil, err
+	}
+	r := f.newGlyphRenderer(t)
+	f.glyphRenderers[c] = r
+	return r, nil
+}
+
+// TilesetGlyphRenderer returns a console.GlyphRenderer drawing glyphs from t.
+func (f *Framebuffer) TilesetGlyphRenderer(t *tileset.Tileset) (console.GlyphRenderer, error) {
+	return f.newGlyphRenderer(t), nil
+}
+
+func (f *Framebuffer) newGlyphRenderer(t *tileset.Tileset) *glyphRenderer {
+	return &glyphRenderer{dst: f.back, tileset: t, missing: make(map[string]bool)}
+}
+
+// Clear starts a new frame.
+func (f *Framebuffer) Clear() {
+	draw.Draw(f.back, f.back.Bounds(), image.NewUniform(clearColor), image.Point{}, draw.Src)
+}
+
+// Present makes the frame drawn since the last call to Clear available through Image.
+func (f *Framebuffer) Present() {
+	copy(f.front.Pix, f.back.Pix)
+}
+
+// Image returns the last presented frame.
+func (f *Framebuffer) Image() *image.RGBA {
+	return f.front
+}
+
+// Screenshot returns a copy of the frame drawn since the last call to Clear.
+func (f *Framebuffer) Screenshot() (image.Image, error) {
+	img := image.NewRGBA(f.back.Bounds())
+	copy(img.Pix, f.back.Pix)
+	return img, nil
+}
+
+// PushEvent queues an input event which is returned by a later call to PollEvent.
+func (f *Framebuffer) PushEvent(e console.Event) {
+	f.events = append(f.events, e)
+}
+
+// PollEvent returns the next queued input event or nil if there is none.
+func (f *Framebuffer) PollEvent() console.Event {
+	if len(f.events) == 0 {
+		return nil
+	}
+	e := f.events[0]
+	f.events = f.events[1:]
+	return e
+}
+
+// Close does nothing, the framebuffer is released by the garbage collector.
+func (f *Framebuffer) Close() {}
+
+// glyphRenderer draws glyphs from a Tileset into an image.
+type glyphRenderer struct {
+	dst     *image.RGBA
+	tileset *tileset.Tileset
+
+	// missing holds the chars not found in the tileset, so that they are only reported once
+	missing map[string]bool
+}
+
+func (r *glyphRenderer) CellSize() (w, h int32) {
+	return r.tileset.CharWidth, r.tileset.CharHeight
+}
+
+func (r *glyphRenderer) PutGlyph(x, y int32, char string, foregroundColor utils.ColorRGBA, backgroundColor utils.ColorRGBA) {
+	w, h := r.CellSize()
+	dst := image.Rect(int(x), int(y), int(x+w), int(y+h))
+
+	// Render background
+	if backgroundColor.A > 0 {
+		c := color.NRGBA{R: backgroundColor.R, G: backgroundColor.G, B: backgroundColor.B, A: backgroundColor.A}
+		draw.Draw(r.dst, dst, image.NewUniform(c), image.Point{}, draw.Over)
+	}
+
+	// Render foreground
+	if len(char) == 0 {
+		return
+	}
+	src, ok := r.tileset.Glyphs[char]
+	if !ok {
+		if !r.missing[char] {
+			log.Printf("Error getting glyph: Glyph for char '%s' not found", char)
+			r.missing[char] = true
+		}
+		return
+	}
+	modulate(r.dst, dst, r.tileset.Image, src.Min, foregroundColor)
+}
+
+// NewCanvas returns a console.Canvas holding an image with the given size in pixels.
+func (r *glyphRenderer) NewCanvas(w, h int32) (console.Canvas, error) {
+	img := image.NewRGBA(image.Rect(0, 0, int(w), int(h)))
+	return &canvas{
+		renderer: &glyphRenderer{dst: img, tileset: r.tileset, missing: r.missing},
+		target:   r.dst,
+	}, nil
+}
+
+// canvas is a console.Canvas drawing into an image which is copied into the target image.
+type canvas struct {
+	renderer *glyphRenderer
+	target   *image.RGBA
+}
+
+func (c *canvas) Update(draw func(r console.GlyphRenderer)) {
+	draw(c)
+}
+
+func (c *canvas) Draw(x, y int32) {
+	over(c.target, image.Pt(int(x), int(y)), c.renderer.dst)
+}
+
+func (c *canvas) Destroy() {}
+
+func (c *canvas) CellSize() (w, h int32) {
+	return c.renderer.CellSize()
+}
+
+// PutGlyph clears the cell at x, y before drawing the glyph into it.
+func (c *canvas) PutGlyph(x, y int32, char string, foregroundColor utils.ColorRGBA, backgroundColor utils.ColorRGBA) {
+	w, h := c.CellSize()
+	draw.Draw(c.renderer.dst, image.Rect(int(x), int(y), int(x+w), int(y+h)), image.Transparent, image.Point{}, draw.Src)
+	c.renderer.PutGlyph(x, y, char, foregroundColor, backgroundColor)
+}
+
+// over draws the premultiplied image src onto dst with its upper left corner at p.
+// Compared to draw.Draw it skips transparent pixels, which make up most of a canvas.
+func over(dst *image.RGBA, p image.Point, src *image.RGBA) {
+	r := src.Bounds().Add(p).Intersect(dst.Bounds())
+	sp := r.Min.Sub(p)
+	for y := 0; y < r.Dy(); y++ {
+		s := src.PixOffset(sp.X, sp.Y+y)
+		d := dst.PixOffset(r.Min.X, r.Min.Y+y)
+		for x := 0; x < r.Dx(); x, s, d = x+1, s+4, d+4 {
+			switch a := uint32(src.Pix[s+3]); a {
+			case 0:
+			case 255:
+				copy(dst.Pix[d:d+4], src.Pix[s:s+4])
+			default:
+				for i := 0; i < 4; i++ {
+					dst.Pix[d+i] = uint8(uint32(src.Pix[s+i]) + uint32(dst.Pix[d+i])*(255-a)/255)
+				}
+			}
+		}
+	}
+}
+
+// modulate draws the part of the glyph image src starting at sp onto the rectangle r of dst.
+// Like a color modulated texture in SDL, the glyph colors are multiplied with c before blending.
+func modulate(dst *image.RGBA, r image.Rectangle, src *image.NRGBA, sp image.Point, c utils.ColorRGBA) {
+	clipped := r.Intersect(dst.Bounds())
+	sp = sp.Add(clipped.Min.Sub(r.Min))
+	for y := 0; y < clipped.Dy(); y++ {
+		for x := 0; x < clipped.Dx(); x++ {
+			s := src.PixOffset(sp.X+x, sp.Y+y)
+			a := uint32(src.Pix[s+3]) * uint32(c.A) / 255
+			if a == 0 {
+				continue
+			}
+			d := dst.PixOffset(clipped.Min.X+x, clipped.Min.Y+y)
+			for i, m := range [3]uint8{c.R, c.G, c.B} {
+				v := uint32(src.Pix[s+i]) * uint32(m) / 255
+				dst.Pix[d+i] = uint8((v*a + uint32(dst.Pix[d+i])*(255-a)) / 255)
+			}
+			dst.Pix[d+3] = uint8(a + uint32(dst.Pix[d+3])*(255-a)/255)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package framebuffer
+
+import (
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/tileset"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+var update = flag.Bool("update", false, "Write the rendered images as new golden files")
+
+var testTileset = tileset.Config{Format: tileset.FormatLibtcod, Path: "../assets/textures/consolas12x12_gs_tc.png"}
+
+// drawScene draws a small map with a border, a text, actors on top of the terrain and a translucent overlay.
+func drawScene(c *console.MatrixConsole) {
+	nx, ny := c.GetDimensions()
+	white := utils.ColorRGBA{R: 255, G: 255, B: 255, A: 255}
+	c.SetLayer(console.LayerTerrain)
+	for y := int32(1); y < ny-1; y++ {
+		for x := int32(1); x < nx-1; x++ {
+			if x%5 == 0 && y%3 == 0 {
+				c.PutCharColor(x, y, "#", utils.ColorRGBA{R: 200, G: 200, B: 200, A: 255}, utils.ColorRGBA{R: 40, G: 40, B: 80, A: 255})
+			} else {
+				c.PutCharColor(x, y, "·", utils.ColorRGBA{R: 220, G: 220, B: 220, A: 100}, utils.ColorRGBA{})
+			}
+		}
+	}
+	c.Border(utils.ColorRGBA{R: 255, A: 255}, utils.ColorRGBA{})
+	c.SetLayer(console.LayerActors)
+	c.PutCharColor(3, 2, "@", utils.ColorRGBA{R: 255, G: 255, B: 0, A: 255}, utils.ColorRGBA{})
+	c.PutCharColor(12, 4, "o", utils.ColorRGBA{R: 255, G: 80, B: 80, A: 255}, utils.ColorRGBA{})
+	for i, r := range "Golden" {
+		c.PutCharColor(2+int32(i), ny-2, string(r), white, utils.ColorRGBA{})
+	}
+	c.SetLayer(console.LayerOverlay)
+	c.SetBackgroundColor(12, 4, utils.ColorRGBA{R: 128, G: 128, B: 128, A: 120})
+}
+
+func TestRenderMatchesGoldenImage(t *testing.T) {
+	const nx, ny = 20, 8
+	probe := New(1, 1)
+	r, err := probe.GlyphRenderer(testTileset)
+	if err != nil {
+		t.Fatalf("Failed to load tileset: %s", err)
+	}
+	cw, ch := r.CellSize()
+
+	f := New(nx*cw, ny*ch)
+	r, err = f.GlyphRenderer(testTileset)
+	if err != nil {
+		t.Fatalf("Failed to load tileset: %s", err)
+	}
+	c := console.NewMatrixConsole(r, nx*cw, ny*ch, nx, ny)
+	drawScene(c)
+	f.Clear()
+	c.Render()
+	f.Present()
+	got := f.Image()
+
+	golden := filepath.Join("testdata", "matrixconsole.png")
+	if *update {
+		file, err := os.Create(golden)
+		if err != nil {
+			t.Fatalf("Failed to create golden file: %s", err)
+		}
+		defer file.Close()
+		if err := png.Encode(file, got); err != nil {
+			t.Fatalf("Failed to write golden file: %s", err)
+		}
+		return
+	}
+
+	file, err := os.Open(golden)
+	if err != nil {
+		t.Fatalf("Failed to open golden file, run the test with -update to create it: %s", err)
+	}
+	defer file.Close()
+	want, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("Failed to decode golden file: %s", err)
+	}
+
+	if got.Bounds() != want.Bounds() {
+		t.Fatalf("Image size is %v, want %v", got.Bounds(), want.Bounds())
+	}
+	if p, ok := firstDifference(got, want); !ok {
+		t.Errorf("Image differs from %s at %v: got %v, want %v", golden, p, got.At(p.X, p.Y), want.At(p.X, p.Y))
+	}
+}
+
+// firstDifference returns the first pixel where a and b differ. The second return value is true if they are equal.
+func firstDifference(a, b image.Image) (image.Point, bool) {
+	bounds := a.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r1, g1, b1, a1 := a.At(x, y).RGBA()
+			r2, g2, b2, a2 := b.At(x, y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				return image.Point{X: x, Y: y}, false
+			}
+		}
+	}
+	return image.Point{}, true
+}
@@ -2,6 +2,7 @@ package game
 
 import (
 	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/utils"
 )
 
 func (g *Game) cleanupEntities() {
@@ -27,5 +28,21 @@ func (g *Game) performPlayerAction(at components.ActionType, intValue int) {
 		// TODO: Implement DropItem
 	case components.ActionTypeUseItem:
 		g.player.Actor.IntValue = intValue
+	case components.ActionTypeUseAbility:
+		g.player.Actor.IntValue = intValue
+		targetX, targetY := g.currentGameMap.GetPositionFromRenderCoordinates(g.mouseTileX, g.mouseTileY)
+		g.player.Actor.Target = utils.Vec2{X: targetX, Y: targetY}
+	}
+}
+
+// toggleSneak switches the sneak mode of the player. It does not take a turn.
+func (g *Game) toggleSneak() {
+	g.player.Stealth.Sneaking = !g.player.Stealth.Sneaking
+	g.player.Stealth.Waited = false
+	if g.player.Stealth.Sneaking {
+		g.ui.AddLogEntry("You start sneaking.")
+	} else {
+		g.ui.AddLogEntry("You stop sneaking.")
 	}
+	g.updateUI()
 }
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/torlenor/asciiventure/ui"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	// cameraDeadzoneX and cameraDeadzoneY are the number of tiles the player may move away from the center
+	// of the view before the camera follows in deadzone mode.
+	cameraDeadzoneX = 8
+	cameraDeadzoneY = 5
+	// cameraPanStep is the number of tiles the camera moves with one scroll command.
+	cameraPanStep = 2
+)
+
+// updateCameraView sets the size of the camera view to the number of map cells visible at the current zoom level.
+func (g *Game) updateCameraView() {
+	nx, ny := g.consoleMap.GetDimensions()
+	g.camera.SetView(int32(float32(nx)/g.renderScale), int32(float32(ny)/g.renderScale))
+}
+
+// mapSize returns the number of tiles of the current map in x and y direction.
+func (g *Game) mapSize() (w, h int32) {
+	maxX, maxY := g.currentGameMap.Dimensions()
+	return maxX + 1, maxY + 1
+}
+
+// resetCamera moves the camera to the player without a transition, e.g., after a map change.
+func (g *Game) resetCamera() {
+	g.camera.SetBounds(g.mapSize())
+	g.camera.Follow(g.player.Position.Current)
+	g.camera.Snap()
+}
+
+// cycleCameraMode switches to the next camera mode.
+func (g *Game) cycleCameraMode() {
+	g.camera.Mode = g.camera.Mode.Next()
+	g.camera.Follow(g.player.Position.Current)
+	g.ui.AddLogEntry(fmt.Sprintf("Camera: %s.", g.camera.Mode))
+}
+
+// updateMinimap shows the parts of the current map the player has seen on the minimap.
+func (g *Game) updateMinimap() {
+	w, h := g.mapSize()
+	portalSeen := g.player.FoV.Seen(g.currentGameMap.MapChangePoint)
+	g.ui.UpdateMinimap(w, h, func(p utils.Vec2) ui.MinimapTile {
+		switch {
+		case p.Equal(g.player.Position.Current):
+			return ui.MinimapTilePlayer
+		case portalSeen && g.currentGameMap.IsPortal(p):
+			return ui.MinimapTilePortal
+		case !g.player.FoV.Seen(p):
+			return ui.MinimapTileUnknown
+		case g.currentGameMap.Opaque(p):
+			return ui.MinimapTileWall
+		default:
+			return ui.MinimapTileFloor
+		}
+	}, g.player.Position.Current)
+}
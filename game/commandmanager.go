@@ -1,7 +1,7 @@
 package game
 
 import (
-	"github.com/veandco/go-sdl2/sdl"
+	"github.com/torlenor/asciiventure/console"
 )
 
 // commandType is the type of command.
@@ -48,6 +48,23 @@ const (
 	CommandAltSelect9
 	CommandAltSelect0
 	CommandDebugReload
+	CommandAbility1
+	CommandAbility2
+	CommandAbility3
+	CommandAbility4
+	CommandDiscard
+	CommandToggleSneak
+	CommandOrderFollow
+	CommandOrderStay
+	CommandOrderAttack
+	CommandSearch
+	CommandScreenshot
+	CommandCycleCamera
+	CommandToggleMinimap
+	CommandToggleSprites
+	CommandToggleTopPanel
+	CommandToggleSidePanel
+	CommandCycleFocus
 )
 
 type commandObserver interface {
@@ -94,86 +111,20 @@ func (c *commandManager) RegisterMouseObserver(observer mouseObserver) {
 	c.mouseObservers = append(c.mouseObservers, observer)
 }
 
-func (c *commandManager) DispatchMouseCommand(event sdl.Event) {
-	var buttonLeftPressed bool
-	var buttonRightPressed bool
-	var buttonMiddlePressed bool
-
-	switch t := event.(type) {
-	case *sdl.MouseMotionEvent:
-		if int32(t.State&sdl.ButtonLMask()) > 0 {
-			buttonLeftPressed = true
-		}
-		if int32(t.State&sdl.ButtonRMask()) > 0 {
-			buttonRightPressed = true
-		}
-		if int32(t.State&sdl.ButtonMMask()) > 0 {
-			buttonMiddlePressed = true
-		}
-		for _, observer := range c.mouseObservers {
-			observer.NotifyMouseCommand(buttonLeftPressed, buttonMiddlePressed, buttonRightPressed, t.X, t.Y)
-		}
-	case *sdl.MouseButtonEvent:
-		if t.State == sdl.PRESSED {
-			switch t.Button {
-			case sdl.BUTTON_LEFT:
-				buttonLeftPressed = true
-			case sdl.BUTTON_MIDDLE:
-				buttonMiddlePressed = true
-			case sdl.BUTTON_RIGHT:
-				buttonRightPressed = true
-			}
-		}
-		for _, observer := range c.mouseObservers {
-			observer.NotifyMouseCommand(buttonLeftPressed, buttonMiddlePressed, buttonRightPressed, -1, -1)
-		}
+func (c *commandManager) DispatchMouseCommand(event console.MouseEvent) {
+	for _, observer := range c.mouseObservers {
+		observer.NotifyMouseCommand(event.Left, event.Middle, event.Right, event.X, event.Y)
 	}
 }
 
 // DispatchCommand will dispatch the command to its observers if it is registered.
-func (c *commandManager) DispatchCommand(event sdl.Event) {
-	key := -1
-	shift := false
-	ctrl := false
-	alt := false
-	pressed := false
-
-	switch t := event.(type) {
-	case *sdl.KeyboardEvent:
-		switch t.Keysym.Mod {
-		case sdl.KMOD_LSHIFT:
-			fallthrough
-		case sdl.KMOD_RSHIFT:
-			fallthrough
-		case sdl.KMOD_SHIFT:
-			shift = true
-		case sdl.KMOD_LCTRL:
-			fallthrough
-		case sdl.KMOD_RCTRL:
-			fallthrough
-		case sdl.KMOD_CTRL:
-			ctrl = true
-		case sdl.KMOD_RALT:
-			fallthrough
-		case sdl.KMOD_LALT:
-			fallthrough
-		case sdl.KMOD_ALT:
-			alt = true
-		}
-
-		if t.State == sdl.PRESSED {
-			pressed = true
-		}
-
-		key = int(t.Keysym.Sym)
-	}
-
+func (c *commandManager) DispatchCommand(event console.KeyEvent) {
 	for _, registeredCommand := range c.registeredCommands {
-		if alt == registeredCommand.alt &&
-			ctrl == registeredCommand.ctrl &&
-			shift == registeredCommand.shift &&
-			pressed == registeredCommand.pressed &&
-			key == registeredCommand.key {
+		if event.Alt == registeredCommand.alt &&
+			event.Ctrl == registeredCommand.ctrl &&
+			event.Shift == registeredCommand.shift &&
+			event.Pressed == registeredCommand.pressed &&
+			event.Key == registeredCommand.key {
 			for _, observer := range c.observers {
 				observer.NotifyCommand(registeredCommand.command)
 			}
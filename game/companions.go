@@ -0,0 +1,116 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	// kittenSpawnChance is the chance in percent that a lost kitten waits on a new map.
+	kittenSpawnChance = 30
+	// companionSpawnRadius is the radius around the spawn point in which companions arrive on a new map.
+	companionSpawnRadius = 3
+)
+
+func (g *Game) createKitten() *entity.Entity {
+	return entity.ParseMonster("./data/monsters/kitten.json")
+}
+
+// createLostKitten places a kitten waiting to be rescued on a random free position of the map.
+func (g *Game) createLostKitten() {
+	maxx, maxy := g.currentGameMap.Dimensions()
+	p := utils.Vec2{X: int32(rand.Intn(int(maxx))), Y: int32(rand.Intn(int(maxy)))}
+	if !g.validLandingSpot(p) {
+		return
+	}
+	e := g.createKitten()
+	if e == nil {
+		return
+	}
+	e.Position = &components.Position{Current: p, Initial: p}
+	e.TargetPosition = p
+	g.entities = append(g.entities, e)
+}
+
+// companions returns the living companions which joined the player.
+func (g *Game) companions() (companions []*entity.Entity) {
+	for _, e := range g.entities {
+		if e.Companion != nil && e.Companion.Recruited && e.IsDead == nil {
+			companions = append(companions, e)
+		}
+	}
+	return
+}
+
+// recruit lets the companion join the player.
+func (g *Game) recruit(e *entity.Entity) {
+	e.Companion.Recruited = true
+	e.Companion.Order = components.CompanionOrderFollow
+	g.ui.AddLogEntry(fmt.Sprintf("%s joins you.", e.Name))
+	g.updateUI()
+}
+
+// orderCompanions gives the order to all companions of the player.
+// Attack orders target the entity under the mouse cursor.
+func (g *Game) orderCompanions(order components.CompanionOrder) {
+	companions := g.companions()
+	if len(companions) == 0 {
+		g.ui.AddLogEntry("You have no companions.")
+		return
+	}
+
+	var target *utils.Vec2
+	if order == components.CompanionOrderAttack {
+		x, y := g.currentGameMap.GetPositionFromRenderCoordinates(g.mouseTileX, g.mouseTileY)
+		p := utils.Vec2{X: x, Y: y}
+		other := g.blockingEntityAt(p)
+		if !g.player.FoV.Visible(p) || other == nil || other == g.player || other.Combat == nil || other.Companion != nil {
+			g.ui.AddLogEntry("There is nothing to attack.")
+			return
+		}
+		target = &p
+	}
+
+	for _, e := range companions {
+		e.Companion.Order = order
+		e.Companion.AttackTarget = target
+	}
+	g.ui.AddLogEntry(fmt.Sprintf("You order your companions: %s.", order))
+	g.updateUI()
+}
+
+// placeCompanions puts the companions near the spawn point of the current map.
+// Companions which do not find a place are left behind.
+func (g *Game) placeCompanions(companions []*entity.Entity) {
+	for _, e := range companions {
+		p, ok := g.findLandingSpot(g.currentGameMap.SpawnPoint, companionSpawnRadius)
+		if !ok {
+			g.ui.AddLogEntry(fmt.Sprintf("%s is left behind.", e.Name))
+			continue
+		}
+		e.Position = &components.Position{Current: p, Initial: p}
+		e.TargetPosition = p
+		e.AI.NoiseTarget = nil
+		e.AI.LastKnownTargetPosition = nil
+		e.AI.PatrolTarget = nil
+		// The traps of the previous map are of no use anymore
+		e.KnownTraps = nil
+		if e.Companion.Order == components.CompanionOrderAttack {
+			e.Companion.Order = components.CompanionOrderFollow
+			e.Companion.AttackTarget = nil
+		}
+		g.entities = append(g.entities, e)
+	}
+}
+
+func (g *Game) updatePartyPane() {
+	var entries []string
+	for _, e := range g.companions() {
+		entries = append(entries, fmt.Sprintf("%s %d/%d HP (%s)", e.Name, e.Health.CurrentHP, e.Health.HP, e.Companion.Order))
+	}
+	g.ui.UpdatePartyPane(entries)
+}
@@ -9,6 +9,17 @@ import (
 	"github.com/torlenor/asciiventure/utils"
 )
 
+var mutagenFiles = []string{
+	"./data/mutagens/eyes_increased_vision.json",
+	"./data/mutagens/core_inventory.json",
+	"./data/mutagens/eyes_xray.json",
+	"./data/mutagens/tail_push.json",
+	"./data/mutagens/core_teleport.json",
+	"./data/mutagens/eyes_teleport_other.json",
+	"./data/mutagens/core_heightened_hearing.json",
+	"./data/mutagens/eyes_night_vision.json",
+}
+
 func (g *Game) createMutagens() {
 	maxx, maxy := g.currentGameMap.Dimensions()
 	for i := 0; i < 20; i++ {
@@ -16,16 +27,7 @@ func (g *Game) createMutagens() {
 		if g.Occupied(p) || !g.currentGameMap.Empty(p) {
 			continue
 		}
-		var e *entity.Entity
-		v := rand.Intn(100)
-		switch {
-		case v < 1*100/3:
-			e = entity.ParseMutagen("./data/mutagens/eyes_increased_vision.json")
-		case v < 2*100/3:
-			e = entity.ParseMutagen("./data/mutagens/core_inventory.json")
-		default:
-			e = entity.ParseMutagen("./data/mutagens/eyes_xray.json")
-		}
+		e := entity.ParseMutagen(mutagenFiles[rand.Intn(len(mutagenFiles))])
 		if e != nil {
 			e.Position = &components.Position{Current: p, Initial: p}
 			e.TargetPosition = p
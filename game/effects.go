@@ -0,0 +1,70 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/torlenor/asciiventure/effects"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/fov"
+	"github.com/torlenor/asciiventure/palette"
+	"github.com/torlenor/asciiventure/pathfinding"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// Durations of the visual effects in frames of the game loop.
+const (
+	damageFlashFrames       = effects.FramesPerSecond / 3
+	damageNumberFrames      = effects.FramesPerSecond
+	fadeInFrames            = effects.FramesPerSecond / 2
+	projectileFramesPerStep = 1
+)
+
+// damageNumberRise is the number of cells a damage number floats up.
+const damageNumberRise = 2
+
+// showDamage flashes the position of e and lets the damage float up from it, if the player can see it.
+func (g *Game) showDamage(e *entity.Entity, dmg int32) {
+	if e.Position == nil || !g.player.FoV.Visible(e.Position.Current) {
+		return
+	}
+	g.effects.Add(&effects.Flash{Position: e.Position.Current, Color: palette.Get(palette.DamageFlash), Duration: damageFlashFrames})
+	g.effects.Add(&effects.FloatingText{
+		Position: e.Position.Current,
+		Text:     fmt.Sprintf("-%d", dmg),
+		Color:    palette.Get(palette.DamageNumber),
+		Rise:     damageNumberRise,
+		Duration: damageNumberFrames,
+	})
+}
+
+// showProjectile lets a projectile fly from one map position to another and flash where it hits,
+// if the player can see either of them.
+func (g *Game) showProjectile(from, to utils.Vec2) {
+	if !g.player.FoV.Visible(from) && !g.player.FoV.Visible(to) {
+		return
+	}
+	trail := &effects.Trail{
+		Path:          pathfinding.DetermineStraightLinePath(from, to),
+		Char:          "*",
+		Color:         palette.Get(palette.Projectile),
+		FramesPerStep: projectileFramesPerStep,
+	}
+	if len(trail.Path) == 0 {
+		return
+	}
+	g.effects.Add(trail)
+	g.effects.AddDelayed(&effects.Flash{Position: to, Color: palette.Get(palette.ImpactFlash), Duration: damageFlashFrames}, trail.ImpactFrame())
+}
+
+// fadeInNewlySeen lets the positions the player sees for the first time fade in.
+func (g *Game) fadeInNewlySeen(previous fov.FoVMap) {
+	newlySeen := g.player.FoV.NewlySeen(previous)
+	if len(newlySeen) == 0 {
+		return
+	}
+	positions := make(map[utils.Vec2]bool, len(newlySeen))
+	for _, p := range newlySeen {
+		positions[p] = true
+	}
+	g.effects.Add(&effects.FadeIn{Positions: positions, Duration: fadeInFrames})
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/torlenor/asciiventure/utils"
 )
 
+// packSpawnRadius is the radius around the first monster of a pack in which the other members spawn.
+const packSpawnRadius = 3
+
 func (g *Game) createEnemyEntities() {
 	maxx, maxy := g.currentGameMap.Dimensions()
 	for i := 0; i < 5; i++ {
@@ -16,23 +19,44 @@ func (g *Game) createEnemyEntities() {
 		if g.Occupied(p) || !g.currentGameMap.Empty(p) {
 			continue
 		}
-		var e *entity.Entity
-		if rand.Intn(100) < 50 {
-			e = g.createMouse()
-
-		} else {
-			e = g.createDog()
+		create := g.createMouse
+		if rand.Intn(100) >= 50 {
+			create = g.createDog
 		}
+		e := create()
 		if e != nil {
 			e.Position = &components.Position{Current: p, Initial: p}
 			e.TargetPosition = p
 			g.entities = append(g.entities, e)
+			if e.AI != nil && e.AI.PackSize > 1 {
+				g.createPack(e, create)
+			}
 		} else {
 			log.Printf("Error creating Mouse entity")
 		}
 	}
 }
 
+// createPack spawns a random number of additional monsters around the leader which hunt together with it.
+func (g *Game) createPack(leader *entity.Entity, create func() *entity.Entity) {
+	pack := components.NewPack()
+	leader.AI.Pack = pack
+	for i := rand.Int31n(leader.AI.PackSize); i > 0; i-- {
+		p, ok := g.findLandingSpot(leader.Position.Current, packSpawnRadius)
+		if !ok {
+			return
+		}
+		e := create()
+		if e == nil {
+			return
+		}
+		e.Position = &components.Position{Current: p, Initial: p}
+		e.TargetPosition = p
+		e.AI.Pack = pack
+		g.entities = append(g.entities, e)
+	}
+}
+
 func (g *Game) createMouse() *entity.Entity {
 	return entity.ParseMonster("./data/monsters/mouse.json")
 }
@@ -0,0 +1,20 @@
+package game
+
+import (
+	"github.com/torlenor/asciiventure/console"
+)
+
+func (g *Game) handleEvents() {
+	for event := g.backend.PollEvent(); event != nil; event = g.backend.PollEvent() {
+		switch t := event.(type) {
+		case console.KeyEvent:
+			g.commandManager.DispatchCommand(t)
+		case console.MouseEvent:
+			g.commandManager.DispatchMouseCommand(t)
+		case console.ResizeEvent:
+			g.resize(t.W, t.H)
+		case console.QuitEvent:
+			g.quit = true
+		}
+	}
+}
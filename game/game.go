@@ -1,49 +1,62 @@
 package game
 
 import (
-	"log"
-
-	"github.com/veandco/go-sdl2/sdl"
-	"github.com/veandco/go-sdl2/ttf"
-
-	"github.com/torlenor/asciiventure/assets"
+	"github.com/torlenor/asciiventure/ai"
+	"github.com/torlenor/asciiventure/camera"
 	"github.com/torlenor/asciiventure/components"
 	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/effects"
 	"github.com/torlenor/asciiventure/entity"
 	"github.com/torlenor/asciiventure/fov"
 	"github.com/torlenor/asciiventure/gamemap"
-	"github.com/torlenor/asciiventure/renderers"
+	"github.com/torlenor/asciiventure/palette"
+	"github.com/torlenor/asciiventure/tileset"
 	"github.com/torlenor/asciiventure/ui"
 	"github.com/torlenor/asciiventure/utils"
 )
 
+// Backends the game can be displayed with.
+const (
+	BackendSDL         = "sdl"
+	BackendTerminal    = "terminal"
+	BackendFramebuffer = "framebuffer"
+)
+
 const (
 	windowName = "Asciiventure"
 
-	fontPath = "./assets/fonts/RobotoMono-Regular.ttf"
-	fontSize = 16
+	// minimapScale is the scale the minimap is drawn with
+	minimapScale = 0.25
 
 	latticeDX = 19
 	latticeDY = 32
 )
 
+// playerFaction is the faction of the player in the faction relations table.
+const playerFaction = "cat"
+
 // Game is the main struct of the game
 type Game struct {
 	debug bool
 
 	quit bool
 
+	// seed for the random number generator, the current time is used if it is 0
+	seed int64
+	// screenshotRequested is true if the next frame shall be saved as screenshot
+	screenshotRequested bool
+
 	screenWidth  int
 	screenHeight int
 	fullscreen   bool
 
-	window   *sdl.Window
-	renderer *renderers.Renderer
+	backend console.Backend
+	// tilesets are the tilesets of the consoles
+	tilesets tilesetConfig
 
 	renderScale float32
-
-	defaultFont  *ttf.Font
-	glyphTexture *assets.GlyphTexture
+	// camera decides which part of the map is shown
+	camera *camera.Camera
 
 	currentGameMap  *gamemap.GameMap
 	currentGamMapID int
@@ -57,6 +70,28 @@ type Game struct {
 	player   *entity.Entity
 	entities []*entity.Entity
 
+	// pendingMutagen is the mutagen waiting for the player to decide which mutation it replaces
+	pendingMutagen *entity.Entity
+	// pendingMapChange is true if the player left the map during the current turn
+	pendingMapChange bool
+	// pendingLevelUps is the number of level ups the player did not yet choose a stat increase for
+	pendingLevelUps int32
+
+	noises       []noise
+	noiseMarkers []utils.Vec2
+
+	// hallucinatedGlyphs are the glyphs shown instead of the real ones while the player hallucinates
+	hallucinatedGlyphs map[*entity.Entity]string
+
+	lightMap *fov.LightMap
+
+	// effects are the running visual effects, which are advanced every frame independent of the turns
+	effects effects.Manager
+
+	// behaviours are the behaviour trees of the monsters referenced by name
+	behaviours map[string]ai.Node
+	factions   components.FactionTable
+
 	time uint
 
 	nextStep  bool
@@ -65,16 +100,33 @@ type Game struct {
 	ui             *ui.UI
 	commandManager *commandManager
 
-	consoleMap      *console.MatrixConsole
-	consoleMainMenu *console.MatrixConsole
+	// consoleMap is the console the map is currently shown in, either consoleMapASCII or consoleMapSprites
+	consoleMap        *console.MatrixConsole
+	consoleMapASCII   *console.MatrixConsole
+	consoleMapSprites *console.MatrixConsole
+	consoleMainMenu   *console.MatrixConsole
 
-	mainMenu *MainMenu
+	// sprites are the glyphs of the graphical tile mode, they are loaded when it is enabled for the first time
+	sprites *tileset.Sprites
+	// glyphs decides which glyphs are drawn for the map, it is nil in ASCII mode
+	glyphs gamemap.Glyphs
+
+	mainMenu    *MainMenu
+	optionsMenu *OptionsMenu
 
 	gameInProgress bool
 }
 
+// SetSeed sets the seed used for all random decisions of the game. It has to be called before Setup.
+func (g *Game) SetSeed(seed int64) {
+	g.seed = seed
+}
+
 // Setup should be called first after creating an instance of Game.
-func (g *Game) Setup(windowWidth, windowHeight int, fullscreen bool) {
+// The backend is one of BackendSDL, BackendTerminal or BackendFramebuffer.
+// The window dimensions are not used by the terminal backend.
+// Call Shutdown also if Setup returns an error, to restore the terminal or close the window.
+func (g *Game) Setup(backend string, windowWidth, windowHeight int, fullscreen bool) error {
 	g.debug = true
 
 	g.renderScale = 1.0
@@ -83,55 +135,51 @@ func (g *Game) Setup(windowWidth, windowHeight int, fullscreen bool) {
 	g.screenHeight = windowHeight
 	g.fullscreen = fullscreen
 
-	g.setupWindow()
-	g.setupRenderer()
-
-	if fullscreen {
-		dm, err := sdl.GetCurrentDisplayMode(0)
-		if err != nil {
-			log.Fatalf("Cannot get current display mode: %s", err)
-		}
-		g.screenWidth = int(dm.W)
-		g.screenHeight = int(dm.H)
+	var err error
+	if g.tilesets, err = loadTilesets(tilesetsPath); err != nil {
+		return err
+	}
+	if err := g.setupBackend(backend); err != nil {
+		return err
+	}
+	if err := g.setupUI(); err != nil {
+		return err
+	}
+	if err := g.setupConsoles(); err != nil {
+		return err
 	}
-
-	g.setupConsoles()
-
-	g.ui = ui.NewUI(g.renderer, g.defaultFont, fontSize)
-	g.ui.SetScreenDimensions(g.screenWidth, g.screenHeight)
 
 	g.gameState = mainMenu
 
 	g.mainMenu = &MainMenu{}
+	if err := g.loadThemes(); err != nil {
+		return err
+	}
 
 	g.setupInput()
-	g.setupGame()
+	return g.setupGame()
 }
 
 // Shutdown should be called when the program quits.
 func (g *Game) Shutdown() {
-	g.glyphTexture.Destroy()
-
-	g.defaultFont.Close()
-	g.renderer.Destroy()
-	g.window.Destroy()
-	sdl.Quit()
-	ttf.Quit()
-}
-
-func (g *Game) createGlyphTexture() {
-	var err error
-	g.glyphTexture, err = assets.NewGlyphTexture(g.renderer, "./assets/textures/ascii_ext_courier.png", "./assets/textures/ascii_ext_courier.json")
-	if err != nil {
-		log.Fatalf("Error creating glyph texture")
+	if g.backend != nil {
+		g.backend.Close()
 	}
 }
 
 func (g *Game) createPlayer() {
-	e := entity.NewEntity("Player", &components.Appearance{Char: "@", Color: utils.ColorRGBA{R: 0, G: 128, B: 255, A: 255}}, utils.Vec2{}, true)
+	e := entity.NewEntity("Player", &components.Appearance{Char: "@", ColorName: string(palette.Player), Color: palette.Get(palette.Player)}, utils.Vec2{}, true)
 	e.Combat = &components.Combat{Power: 5, Defense: 2}
 	e.Health = &components.Health{CurrentHP: 40, HP: 40}
 	e.Vision = &components.Vision{Range: 20}
+	e.Hearing = &components.Hearing{Threshold: playerHearingThreshold}
+	e.Energy = &components.Energy{Max: 20, Current: 20, Regeneration: 1}
+	e.Instability = &components.Instability{}
+	e.Stealth = &components.Stealth{}
+	e.Faction = &components.Faction{Name: playerFaction}
+	e.Experience = &components.Experience{Level: 1}
+	e.Satiation = &components.Satiation{Current: playerMaxSatiation, Max: playerMaxSatiation}
+	e.Perception = &components.Perception{Value: 3}
 	g.entities = append(g.entities, e)
 	g.player = e
 }
@@ -151,34 +199,65 @@ func (g *Game) setTargetPosition(x, y int32) {
 	g.player.TargetPosition = utils.Vec2{X: x, Y: y}
 }
 
+// setScale sets the scale of the rendered output, if the backend supports it.
+func (g *Game) setScale(scale float32) {
+	if s, ok := g.backend.(console.Scaler); ok {
+		s.SetScale(scale, scale)
+	}
+}
+
+// zoom changes the scale the map is rendered with by delta, if the backend supports it.
+func (g *Game) zoom(delta float32) {
+	if _, ok := g.backend.(console.Scaler); !ok {
+		return
+	}
+	g.renderScale += delta
+	g.setMapConsole(g.consoleMap)
+}
+
 func (g *Game) drawMainMenu() {
-	g.renderer.GetRenderer().SetClipRect(nil)
-	g.renderer.SetScale(1, 1)
-	g.renderer.Clear()
+	g.setScale(1)
+	g.backend.Clear()
 
 	g.consoleMainMenu.Clear()
-	g.mainMenu.Render(g.consoleMainMenu, g.gameInProgress)
+	if g.gameState == optionsMenu {
+		g.optionsMenu.Render(g.consoleMainMenu)
+	} else {
+		g.mainMenu.Render(g.consoleMainMenu, g.gameInProgress)
+	}
 	g.consoleMainMenu.Render()
 
-	g.renderer.Present()
+	g.backend.Present()
 }
 
 func (g *Game) draw() {
-	g.renderer.GetRenderer().SetClipRect(nil)
-	g.renderer.SetScale(g.renderScale, g.renderScale)
-	g.renderer.Clear()
+	g.render()
+	if g.screenshotRequested {
+		g.screenshotRequested = false
+		g.saveScreenshot()
+	}
+	g.backend.Present()
+}
+
+// render draws the map and the UI into a new frame without presenting it.
+func (g *Game) render() {
+	g.setScale(g.renderScale)
+	g.backend.Clear()
 
 	g.consoleMap.Clear()
-	g.currentGameMap.Render(g.consoleMap, g.player.FoV, g.player, g.entities, int32(g.renderer.OriginX), int32(g.renderer.OriginY))
+	g.camera.Follow(g.player.Position.Current)
+	offsetX, offsetY := g.camera.Offset()
+	g.currentGameMap.Render(g.consoleMap, g.player.FoV, g.lightMap, g.effects.Opacity, g.glyphs, g.entities, offsetX, offsetY)
 	if g.gameState != gameOver && g.gameState != mainMenu {
+		g.renderHallucinations()
+		g.renderNoiseMarkers()
+		g.effects.Render(g.consoleMap, g.currentGameMap)
 		g.renderMouseTile()
 	}
 	g.consoleMap.Render()
 
-	g.renderer.SetScale(1, 1)
+	g.setScale(1)
 	g.ui.Render()
-
-	g.renderer.Present()
 }
 
 func (g *Game) timestep() {
@@ -188,27 +267,39 @@ func (g *Game) timestep() {
 
 		g.pickupSystem()
 		g.useSystem()
+		g.mutationSystem()
+		g.trapSystem()
+		g.noiseSystem()
+		g.instabilitySystem()
+		g.hungerSystem()
 		g.regenerationSystem()
 
+		g.advanceTime()
 		g.updateFoVs()
 
-		g.time++
+		if g.pendingMapChange {
+			g.pendingMapChange = false
+			g.selectGameMap(g.currentGamMapID + 1)
+		}
+
 		g.nextStep = false
 
 		g.ui.SetStatusBarText("")
 		g.updateUI()
+		g.promptLevelUp()
 	}
 }
 
 func (g *Game) updateFoVs() {
+	previous := g.player.FoV.Copy()
+	g.updateLightMap()
 	for _, e := range g.entities {
 		if e.Position == nil || e.Vision == nil {
 			continue
 		}
-		if e.Mutations.Has(components.MutationEffectXRay) {
-			fov.UpdateFoV(g.currentGameMap, e.FoV, e.Vision.Range+e.Mutations.GetData(components.MutationEffectIncreasedVision), e.Position.Current, true)
-		} else {
-			fov.UpdateFoV(g.currentGameMap, e.FoV, e.Vision.Range+e.Mutations.GetData(components.MutationEffectIncreasedVision), e.Position.Current, false)
-		}
+		viewRange := e.Vision.Range + e.Mutations.GetData(components.MutationEffectIncreasedVision)
+		darkVisionRange := baseDarkVisionRange + e.Mutations.GetData(components.MutationEffectNightVision)
+		fov.UpdateFoVWithLight(g.currentGameMap, e.FoV, viewRange, e.Position.Current, e.Mutations.Has(components.MutationEffectXRay), g.lightMap, darkVisionRange)
 	}
+	g.fadeInNewlySeen(previous)
 }
@@ -3,24 +3,28 @@ package game
 import (
 	"fmt"
 	"time"
+
+	"github.com/torlenor/asciiventure/effects"
 )
 
 // GameLoop is a blocking function actually running the game.
 func (g *Game) GameLoop() {
-	ticker := time.NewTicker(time.Second / 15)
+	ticker := time.NewTicker(time.Second / effects.FramesPerSecond)
 	for !g.quit {
 		start := time.Now()
-		g.handleSDLEvents()
+		g.handleEvents()
 		if g.gameState != gameOver {
 			g.timestep()
 		}
 		gameLogicUpdateMs := float32(time.Now().Sub(start).Microseconds()) / 1000.0
 
 		start = time.Now()
-		if g.gameState == mainMenu {
+		if g.gameState == mainMenu || g.gameState == optionsMenu {
 			g.drawMainMenu()
 		} else {
 			g.draw()
+			g.effects.Advance()
+			g.camera.Update()
 		}
 		drawUpdateMs := float32(time.Now().Sub(start).Microseconds()) / 1000.0
 		start = time.Now()
@@ -2,20 +2,23 @@ package game
 
 import (
 	"bufio"
+	"fmt"
 	"io/ioutil"
 	"log"
+	"math/rand"
 	"os"
 	"path"
 
 	"github.com/torlenor/asciiventure/components"
 	"github.com/torlenor/asciiventure/entity"
 	"github.com/torlenor/asciiventure/gamemap"
+	"github.com/torlenor/asciiventure/utils"
 )
 
-func (g *Game) loadGameMapsFromDirectory(dir string) {
+func (g *Game) loadGameMapsFromDirectory(dir string) error {
 	files, err := ioutil.ReadDir(dir)
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("Error reading map directory: %s", err)
 	}
 	for _, f := range files {
 		if !f.IsDir() {
@@ -29,7 +32,7 @@ func (g *Game) loadGameMapsFromDirectory(dir string) {
 				continue
 			}
 			r4 := bufio.NewReader(f)
-			r, err := gamemap.NewGameMapFromReader(r4, g.glyphTexture)
+			r, err := gamemap.NewGameMapFromReader(r4)
 			if err != nil {
 				log.Printf("Error reading room file: %s", err)
 				continue
@@ -38,13 +41,11 @@ func (g *Game) loadGameMapsFromDirectory(dir string) {
 			g.loadedGameMaps = append(g.loadedGameMaps, &r)
 		}
 	}
+	return nil
 }
 
 func (g *Game) selectGameMap(r int) {
 	// TODO: Do not pre-generate/pre-load maps but generate them on map change
-	if len(g.loadedGameMaps) == 0 {
-		log.Fatalf("No maps loaded")
-	}
 	g.currentGamMapID = r
 	r--
 	if r < 0 || r >= len(g.loadedGameMaps) {
@@ -53,16 +54,25 @@ func (g *Game) selectGameMap(r int) {
 
 	g.currentGameMap = g.loadedGameMaps[r]
 
+	companions := g.companions()
 	g.player.FoV.ClearSeen()
 	g.entities = []*entity.Entity{g.player}
+	g.noises = []noise{}
+	g.noiseMarkers = []utils.Vec2{}
+	g.effects.Clear()
 	g.player.Position = &components.Position{
 		Current: g.currentGameMap.SpawnPoint,
 	}
 	g.player.TargetPosition = g.player.Position.Current
+	g.placeCompanions(companions)
 	g.createEnemyEntities()
+	if rand.Intn(100) < kittenSpawnChance {
+		g.createLostKitten()
+	}
 	g.createItems()
 	g.createMutagens()
 	g.updateFoVs()
+	g.resetCamera()
 	g.ui.AddLogEntry("Map changed.")
 
 	g.updateUI()
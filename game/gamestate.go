@@ -7,8 +7,11 @@ const (
 	playersTurn
 	enemyTurn
 	gameOver
+	mutationReplacePrompt
+	levelUpPrompt
+	optionsMenu
 )
 
 func (d gameState) String() string {
-	return [...]string{"mainMenu", "playersTurn", "enemyTurn", "gameOver"}[d]
+	return [...]string{"mainMenu", "playersTurn", "enemyTurn", "gameOver", "mutationReplacePrompt", "levelUpPrompt", "optionsMenu"}[d]
 }
@@ -1,9 +1,11 @@
 package game
 
 import (
+	"log"
+
 	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/console"
 	"github.com/torlenor/asciiventure/utils"
-	"github.com/veandco/go-sdl2/sdl"
 )
 
 func (g *Game) setupInput() {
@@ -11,13 +13,13 @@ func (g *Game) setupInput() {
 	g.commandManager.RegisterObserver(g)
 	g.commandManager.RegisterMouseObserver(g)
 
-	g.commandManager.RegisterCommand(CommandQuit, "quit", sdl.K_ESCAPE, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandQuit, "quit", console.KeyEscape, false, false, false, true)
 	g.commandManager.RegisterCommand(CommandQuit, "quit", int('q'), false, false, false, true)
 
-	g.commandManager.RegisterCommand(CommandMoveN, "move_n", sdl.K_UP, false, false, false, true)
-	g.commandManager.RegisterCommand(CommandMoveE, "move_e", sdl.K_RIGHT, false, false, false, true)
-	g.commandManager.RegisterCommand(CommandMoveS, "move_s", sdl.K_DOWN, false, false, false, true)
-	g.commandManager.RegisterCommand(CommandMoveW, "move_w", sdl.K_LEFT, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandMoveN, "move_n", console.KeyUp, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandMoveE, "move_e", console.KeyRight, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandMoveS, "move_s", console.KeyDown, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandMoveW, "move_w", console.KeyLeft, false, false, false, true)
 
 	// y    k       u
 	// h            l
@@ -31,16 +33,36 @@ func (g *Game) setupInput() {
 	g.commandManager.RegisterCommand(CommandMoveSW, "move_sw", int('b'), false, false, false, true)
 	g.commandManager.RegisterCommand(CommandMoveNW, "move_nw", int('y'), false, false, false, true)
 
-	g.commandManager.RegisterCommand(CommandNextTimeStep, "next_timestep", sdl.K_SPACE, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandNextTimeStep, "next_timestep", console.KeySpace, false, false, false, true)
 	g.commandManager.RegisterCommand(CommandZoomIn, "zoom_in", int('+'), false, false, false, true)
 	g.commandManager.RegisterCommand(CommandZoomOut, "zoom_out", int('-'), false, false, false, true)
-	g.commandManager.RegisterCommand(CommandScrollUp, "scroll_up", sdl.K_UP, false, false, true, true)
-	g.commandManager.RegisterCommand(CommandScrollLeft, "scroll_left", sdl.K_LEFT, false, false, true, true)
-	g.commandManager.RegisterCommand(CommandScrollDown, "scroll_down", sdl.K_DOWN, false, false, true, true)
-	g.commandManager.RegisterCommand(CommandScrollRight, "scroll_right", sdl.K_RIGHT, false, false, true, true)
+	g.commandManager.RegisterCommand(CommandScrollUp, "scroll_up", console.KeyUp, false, false, true, true)
+	g.commandManager.RegisterCommand(CommandScrollLeft, "scroll_left", console.KeyLeft, false, false, true, true)
+	g.commandManager.RegisterCommand(CommandScrollDown, "scroll_down", console.KeyDown, false, false, true, true)
+	g.commandManager.RegisterCommand(CommandScrollRight, "scroll_right", console.KeyRight, false, false, true, true)
+	g.commandManager.RegisterCommand(CommandCycleCamera, "cycle_camera", int('c'), true, false, false, true)
+	g.commandManager.RegisterCommand(CommandToggleMinimap, "toggle_minimap", int('m'), false, false, false, true)
+	g.commandManager.RegisterCommand(CommandToggleSprites, "toggle_sprites", console.KeyF2, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandToggleTopPanel, "toggle_top_panel", console.KeyF3, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandToggleSidePanel, "toggle_side_panel", console.KeyF4, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandCycleFocus, "cycle_focus", console.KeyTab, false, false, false, true)
 
 	g.commandManager.RegisterCommand(CommandInteract, "interact", int('g'), false, false, false, true)
-	g.commandManager.RegisterCommand(CommandInteract, "interact", sdl.K_RETURN, false, false, false, true)
+	g.commandManager.RegisterCommand(CommandInteract, "interact", console.KeyReturn, false, false, false, true)
+
+	g.commandManager.RegisterCommand(CommandAbility1, "ability_1", int('z'), false, false, false, true)
+	g.commandManager.RegisterCommand(CommandAbility2, "ability_2", int('x'), false, false, false, true)
+	g.commandManager.RegisterCommand(CommandAbility3, "ability_3", int('c'), false, false, false, true)
+	g.commandManager.RegisterCommand(CommandAbility4, "ability_4", int('v'), false, false, false, true)
+
+	g.commandManager.RegisterCommand(CommandDiscard, "discard", int('d'), false, false, false, true)
+	g.commandManager.RegisterCommand(CommandToggleSneak, "toggle_sneak", int('s'), false, false, false, true)
+	g.commandManager.RegisterCommand(CommandSearch, "search", int('e'), false, false, false, true)
+	g.commandManager.RegisterCommand(CommandScreenshot, "screenshot", console.KeyF12, false, false, false, true)
+
+	g.commandManager.RegisterCommand(CommandOrderFollow, "order_follow", int('f'), false, false, false, true)
+	g.commandManager.RegisterCommand(CommandOrderStay, "order_stay", int('t'), false, false, false, true)
+	g.commandManager.RegisterCommand(CommandOrderAttack, "order_attack", int('a'), false, false, false, true)
 
 	g.commandManager.RegisterCommand(CommandSelect1, "select_1", int('1'), false, false, false, true)
 	g.commandManager.RegisterCommand(CommandSelect2, "select_2", int('2'), false, false, false, true)
@@ -62,12 +84,22 @@ func (g *Game) setupInput() {
 		g.commandManager.RegisterCommand(CommandAltSelect7, "select_map_7", int('7'), false, false, true, true)
 		g.commandManager.RegisterCommand(CommandAltSelect8, "select_map_8", int('8'), false, false, true, true)
 		g.commandManager.RegisterCommand(CommandAltSelect9, "select_map_9", int('9'), false, false, true, true)
-		g.commandManager.RegisterCommand(CommandDebugReload, "reload", sdl.K_F5, false, false, false, true)
+		g.commandManager.RegisterCommand(CommandDebugReload, "reload", console.KeyF5, false, false, false, true)
 	}
 }
 
 // NotifyCommand will be called from commandManager when a registered command is received.
 func (g *Game) NotifyCommand(command command) {
+	if g.gameState == playersTurn || g.gameState == levelUpPrompt || g.gameState == mutationReplacePrompt {
+		if command == CommandCycleFocus {
+			g.ui.FocusNext()
+			return
+		}
+		if in, ok := uiInputs[command]; ok && g.ui.HasFocus() && g.ui.HandleInput(in) {
+			return
+		}
+	}
+
 	if g.gameState == gameOver {
 		switch command {
 		case CommandQuit:
@@ -122,24 +154,59 @@ func (g *Game) NotifyCommand(command command) {
 			g.player.TargetPosition.Y = g.player.Position.Current.Y - 1
 			g.nextStep = true
 		case CommandScrollUp:
-			g.renderer.OriginY += 2
+			g.camera.Pan(0, -cameraPanStep)
 		case CommandScrollLeft:
-			g.renderer.OriginX += 2
+			g.camera.Pan(-cameraPanStep, 0)
 		case CommandScrollDown:
-			g.renderer.OriginY -= 2
+			g.camera.Pan(0, cameraPanStep)
 		case CommandScrollRight:
-			g.renderer.OriginX -= 2
+			g.camera.Pan(cameraPanStep, 0)
+		case CommandCycleCamera:
+			g.cycleCameraMode()
+		case CommandToggleMinimap:
+			g.ui.ToggleMinimap()
+		case CommandToggleSprites:
+			g.toggleSprites()
+		case CommandToggleTopPanel:
+			g.ui.ToggleTopPanel()
+			g.relayout()
+		case CommandToggleSidePanel:
+			g.ui.ToggleSidePanel()
+			g.relayout()
 		case CommandZoomIn:
-			g.renderScale += 0.1
-			g.consoleMap.SetOffset(0, int32(float32(g.screenHeight/6)/g.renderScale))
+			g.zoom(0.1)
 		case CommandZoomOut:
-			g.renderScale -= 0.1
-			g.consoleMap.SetOffset(0, int32(float32(g.screenHeight/6)/g.renderScale))
+			g.zoom(-0.1)
 		case CommandNextTimeStep:
 			g.nextStep = true
 		case CommandInteract:
 			g.performPlayerAction(components.ActionTypeInteract, 0)
 			g.nextStep = true
+		case CommandToggleSneak:
+			g.toggleSneak()
+		case CommandSearch:
+			g.performPlayerAction(components.ActionTypeSearch, 0)
+			g.nextStep = true
+		case CommandScreenshot:
+			g.screenshotRequested = true
+		case CommandOrderFollow:
+			g.orderCompanions(components.CompanionOrderFollow)
+		case CommandOrderStay:
+			g.orderCompanions(components.CompanionOrderStay)
+		case CommandOrderAttack:
+			g.orderCompanions(components.CompanionOrderAttack)
+		case CommandAbility1:
+			g.performPlayerAction(components.ActionTypeUseAbility, 0)
+			g.nextStep = true
+		case CommandAbility2:
+			g.performPlayerAction(components.ActionTypeUseAbility, 1)
+			g.nextStep = true
+		case CommandAbility3:
+			g.performPlayerAction(components.ActionTypeUseAbility, 2)
+			g.nextStep = true
+		case CommandAbility4:
+			g.performPlayerAction(components.ActionTypeUseAbility, 3)
+			g.nextStep = true
 		case CommandSelect1:
 			g.performPlayerAction(components.ActionTypeUseItem, 0)
 			g.nextStep = true
@@ -186,7 +253,45 @@ func (g *Game) NotifyCommand(command command) {
 		case CommandAltSelect9:
 			g.selectGameMap(9)
 		case CommandDebugReload:
-			g.loadGameMapsFromDirectory("./assets/rooms")
+			if err := g.loadGameMapsFromDirectory("./assets/rooms"); err != nil {
+				log.Printf("%s", err)
+			}
+		}
+	} else if g.gameState == levelUpPrompt {
+		switch command {
+		case CommandSelect1:
+			g.levelUp(0)
+		case CommandSelect2:
+			g.levelUp(1)
+		case CommandSelect3:
+			g.levelUp(2)
+		case CommandSelect4:
+			g.levelUp(3)
+		}
+	} else if g.gameState == mutationReplacePrompt {
+		switch command {
+		case CommandQuit:
+			g.endMutationPrompt()
+		case CommandDiscard:
+			g.discardPendingMutagen()
+		case CommandSelect1:
+			g.replaceMutation(0)
+		case CommandSelect2:
+			g.replaceMutation(1)
+		case CommandSelect3:
+			g.replaceMutation(2)
+		case CommandSelect4:
+			g.replaceMutation(3)
+		case CommandSelect5:
+			g.replaceMutation(4)
+		case CommandSelect6:
+			g.replaceMutation(5)
+		case CommandSelect7:
+			g.replaceMutation(6)
+		case CommandSelect8:
+			g.replaceMutation(7)
+		case CommandSelect9:
+			g.replaceMutation(8)
 		}
 	} else if g.gameState == mainMenu {
 		switch command {
@@ -197,6 +302,8 @@ func (g *Game) NotifyCommand(command command) {
 				g.gameState = playersTurn
 				g.player.IsDead = nil
 				// TODO: Reset everything and generate new maps when a new game starts
+			case MainMenuActionOptions:
+				g.gameState = optionsMenu
 			case MainMenuActionQuit:
 				g.quit = true
 			}
@@ -211,6 +318,23 @@ func (g *Game) NotifyCommand(command command) {
 		case CommandMoveW:
 			g.mainMenu.MoveCursor(-1, 0)
 		}
+	} else if g.gameState == optionsMenu {
+		switch command {
+		case CommandInteract:
+			if g.optionsMenu.Select() == OptionsMenuActionBack {
+				g.gameState = mainMenu
+			}
+		case CommandQuit:
+			g.gameState = mainMenu
+		case CommandMoveN:
+			g.optionsMenu.MoveCursor(0, -1)
+		case CommandMoveE:
+			g.optionsMenu.MoveCursor(1, 0)
+		case CommandMoveS:
+			g.optionsMenu.MoveCursor(0, 1)
+		case CommandMoveW:
+			g.optionsMenu.MoveCursor(-1, 0)
+		}
 	}
 }
 
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/ui"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// resize adapts the UI and the consoles to a screen with the new width and height in screen units.
+func (g *Game) resize(width, height int32) {
+	g.screenWidth = int(width)
+	g.screenHeight = int(height)
+	g.ui.SetScreenDimensions(g.screenWidth, g.screenHeight)
+	g.relayout()
+}
+
+// relayout fits the consoles to the current screen dimensions and the panes of the UI.
+// It has to be called whenever the map pane of the UI changed.
+func (g *Game) relayout() {
+	pane := g.ui.MapPane()
+	fitMapConsole(g.consoleMapASCII, pane)
+	if g.consoleMapSprites != nil {
+		fitMapConsole(g.consoleMapSprites, pane)
+	}
+	g.setMapConsole(g.consoleMap)
+	g.fitMainMenuConsole()
+	if g.currentGameMap != nil {
+		g.updateMinimap()
+	}
+}
+
+// fitMapConsole resizes c to fill the pane given in screen units.
+func fitMapConsole(c *console.MatrixConsole, pane ui.Rect) {
+	charWidth, charHeight := c.GetCellSize()
+	c.Resize(pane.W, pane.H, pane.W/charWidth, pane.H/charHeight)
+}
+
+// fitMainMenuConsole resizes the main menu console to be centered on the screen,
+// with at most mainMenuWidth x mainMenuHeight cells.
+func (g *Game) fitMainMenuConsole() {
+	availableWidth := int32(g.screenWidth)
+	availableHeight := int32(g.screenHeight)
+	charWidth, charHeight := g.consoleMainMenu.GetCellSize()
+	g.consoleMainMenu.Resize(availableWidth, availableHeight, utils.MinInt32(mainMenuWidth, availableWidth/charWidth), utils.MinInt32(mainMenuHeight, availableHeight/charHeight))
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/ui"
+)
+
+// levelUpChoice is a stat increase the player can choose on level up.
+type levelUpChoice struct {
+	description string
+	apply       func(e *entity.Entity)
+}
+
+var levelUpChoices = []levelUpChoice{
+	{"+10 max HP", func(e *entity.Entity) { e.Health.HP += 10; e.Health.CurrentHP += 10 }},
+	{"+1 Power", func(e *entity.Entity) { e.Combat.Power++ }},
+	{"+1 Defense", func(e *entity.Entity) { e.Combat.Defense++ }},
+	{"+5 max Energy", func(e *entity.Entity) { e.Energy.Max += 5; e.Energy.Current += 5 }},
+}
+
+// gainExperience gives the experience reward of the killed entity to the killer.
+func (g *Game) gainExperience(killer *entity.Entity, killed *entity.Entity) {
+	if killer.Experience == nil || killed.Experience == nil || killed.Experience.Reward == 0 {
+		return
+	}
+	levels := killer.Experience.Add(killed.Experience.Reward)
+	if killer != g.player {
+		return
+	}
+	g.ui.AddLogEntry(fmt.Sprintf("You gain %d XP.", killed.Experience.Reward))
+	if levels > 0 {
+		g.ui.AddLogEntry(fmt.Sprintf("You reached level %d.", killer.Experience.Level))
+		g.pendingLevelUps += levels
+	}
+}
+
+// promptLevelUp shows the level up screen if the player has level ups left to spend
+// and is not busy with another prompt.
+func (g *Game) promptLevelUp() {
+	if g.pendingLevelUps <= 0 || (g.gameState != playersTurn && g.gameState != levelUpPrompt) {
+		return
+	}
+	var options []string
+	for i, c := range levelUpChoices {
+		options = append(options, fmt.Sprintf("%d) %s", i+1, c.description))
+	}
+	g.ui.ShowDialog(ui.Dialog{
+		Title:    "Level up!",
+		Text:     []string{fmt.Sprintf("Choose an improvement (%d left):", g.pendingLevelUps), ""},
+		Options:  options,
+		Hints:    []ui.KeyHint{{Key: fmt.Sprintf("1-%d", len(levelUpChoices)), Description: "choose"}, {Key: "Enter", Description: "choose"}},
+		OnSelect: g.levelUp,
+	})
+	g.gameState = levelUpPrompt
+}
+
+// levelUp applies the n-th level up choice to the player.
+func (g *Game) levelUp(n int) {
+	if n < 0 || n >= len(levelUpChoices) {
+		return
+	}
+	levelUpChoices[n].apply(g.player)
+	g.ui.AddLogEntry(fmt.Sprintf("You feel stronger: %s.", levelUpChoices[n].description))
+	g.pendingLevelUps--
+	if g.pendingLevelUps > 0 {
+		g.promptLevelUp()
+	} else {
+		g.ui.HideDialog()
+		g.gameState = playersTurn
+	}
+	g.updateUI()
+}
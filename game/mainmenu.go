@@ -2,21 +2,13 @@ package game
 
 import (
 	"github.com/torlenor/asciiventure/console"
-	"github.com/torlenor/asciiventure/utils"
+	"github.com/torlenor/asciiventure/palette"
 )
 
 const (
 	maxOptions = 4
 )
 
-var (
-	fc = utils.ColorRGBA{R: 255, G: 255, B: 255, A: 255}
-	bc = utils.ColorRGBA{}
-
-	fcSelected = utils.ColorRGBA{R: 0, G: 0, B: 0, A: 255}
-	bcSelected = utils.ColorRGBA{R: 255, G: 255, B: 255, A: 255}
-)
-
 // TODO: Move MainMenu out of the 'game' package
 
 // MainMenu represents the main menu of the game
@@ -67,6 +59,9 @@ func (g *MainMenu) Render(console *console.MatrixConsole, gameInProgress bool) {
 		"                      Quit",
 	}
 
+	fc, bc := palette.Get(palette.MenuText), palette.Get(palette.MenuBackground)
+	fcSelected, bcSelected := palette.Get(palette.MenuSelectedText), palette.Get(palette.MenuSelectedBackground)
+
 	console.Clear()
 	x := int32(0)
 	y := int32(0)
@@ -1,13 +1,15 @@
 package game
 
 import (
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/palette"
 	"github.com/torlenor/asciiventure/pathfinding"
 	"github.com/torlenor/asciiventure/utils"
 )
 
 func (g *Game) determinePathPlayerMouse() []utils.Vec2 {
 	targetX, targetY := g.currentGameMap.GetPositionFromRenderCoordinates(g.mouseTileX, g.mouseTileY)
-	return pathfinding.DetermineAstarPath(g.currentGameMap, g, g.player.Position.Current, utils.Vec2{X: int32(targetX), Y: int32(targetY)})
+	return pathfinding.DetermineAstarPath(pathfinding.WithCosts(g.currentGameMap, trapCosts{g: g, e: g.player}), g, g.player.Position.Current, utils.Vec2{X: int32(targetX), Y: int32(targetY)})
 }
 
 func (g *Game) updateMouseTile(x, y int) {
@@ -19,14 +21,15 @@ func (g *Game) updateMouseTile(x, y int) {
 }
 
 func (g *Game) renderMouseTile() {
+	g.consoleMap.SetLayer(console.LayerOverlay)
 	if !g.player.Position.Current.Equal(g.player.TargetPosition) {
 		path := g.movementPath
 		for _, p := range path {
 			notEmpty := !g.currentGameMap.Empty(p) && g.player.FoV.Visible(p)
 			_, blocked := g.blocked(p)
-			color := utils.ColorRGBA{R: 100, G: 100, B: 255, A: 64}
+			color := palette.Get(palette.PathFree)
 			if notEmpty || blocked {
-				color = utils.ColorRGBA{R: 255, G: 80, B: 80, A: 100}
+				color = palette.Get(palette.PathBlocked)
 			}
 			rx, ry := g.currentGameMap.GetRenderCoordinatesFromPosition(int32(p.X), int32(p.Y))
 			g.consoleMap.SetBackgroundColor(rx, ry, color)
@@ -36,6 +39,6 @@ func (g *Game) renderMouseTile() {
 		}
 	}
 
-	color := utils.ColorRGBA{R: 128, G: 128, B: 128, A: 120}
+	color := palette.Get(palette.MouseTile)
 	g.consoleMap.SetBackgroundColor(g.mouseTileX, g.mouseTileY, color)
 }
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/ui"
+)
+
+// promptMutationReplacement asks the player what to do with a mutagen for a category without free slots.
+func (g *Game) promptMutationReplacement(mutagen *entity.Entity) {
+	g.pendingMutagen = mutagen
+	g.gameState = mutationReplacePrompt
+	category := mutagen.Mutagen.Category
+	var options []string
+	for i, index := range g.player.Mutations.InCategory(category) {
+		options = append(options, fmt.Sprintf("%d) %s", i+1, g.player.Mutations[index].Effect))
+	}
+	g.ui.ShowDialog(ui.Dialog{
+		Title:   mutagen.Name,
+		Text:    []string{fmt.Sprintf("All %s slots are taken. Choose the mutation to replace:", category), ""},
+		Options: options,
+		Hints: []ui.KeyHint{
+			{Key: fmt.Sprintf("1-%d", category.Slots()), Description: "replace"},
+			{Key: "d", Description: "discard"},
+			{Key: "Esc", Description: "leave it"},
+		},
+		OnSelect: g.replaceMutation,
+		OnCancel: g.endMutationPrompt,
+	})
+}
+
+// replaceMutation replaces the n-th mutation in the category of the pending mutagen with it.
+func (g *Game) replaceMutation(n int) {
+	indices := g.player.Mutations.InCategory(g.pendingMutagen.Mutagen.Category)
+	if n < 0 || n >= len(indices) {
+		return
+	}
+	for _, r := range g.player.ReplaceMutation(g.pendingMutagen, indices[n]) {
+		switch r.Type {
+		case entity.ActionResultMessage:
+			g.ui.AddLogEntry(r.StringValue)
+		case entity.ActionResultInstabilityEvent:
+			g.mutationEvent(g.player)
+		}
+	}
+	if !g.player.Mutations.Has(components.MutationEffectInventory) {
+		g.dropAllItems(g.player)
+	}
+	g.endMutationPrompt()
+}
+
+// discardPendingMutagen destroys the pending mutagen without consuming it.
+func (g *Game) discardPendingMutagen() {
+	g.pendingMutagen.Position = nil
+	g.ui.AddLogEntry(fmt.Sprintf("%s discarded %s.", g.player.Name, g.pendingMutagen.Name))
+	g.endMutationPrompt()
+}
+
+func (g *Game) endMutationPrompt() {
+	g.ui.HideDialog()
+	g.pendingMutagen = nil
+	g.gameState = playersTurn
+	g.updateUI()
+	g.promptLevelUp()
+}
+
+// dropAllItems drops everything in the inventory of e at its current position.
+func (g *Game) dropAllItems(e *entity.Entity) {
+	for _, item := range append([]*entity.Entity{}, e.Inventory.Items...) {
+		for _, r := range e.DropItem(item) {
+			if r.Type == entity.ActionResultMessage {
+				g.ui.AddLogEntry(r.StringValue)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/palette"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// themesPath is the directory holding the themes which can be selected in the options menu.
+const themesPath = "./data/themes"
+
+// loadThemes creates the options menu with the Default theme and the themes in themesPath.
+func (g *Game) loadThemes() error {
+	themes, err := palette.LoadThemes(themesPath)
+	if err != nil {
+		return fmt.Errorf("Error loading themes: %s", err)
+	}
+	g.optionsMenu = NewOptionsMenu(themes)
+	return nil
+}
+
+// OptionsMenuActionType holds the type of result.
+type OptionsMenuActionType int
+
+// List of OptionsMenuActionTypes.
+const (
+	OptionsMenuActionUnknown OptionsMenuActionType = iota
+	OptionsMenuActionNextTheme
+	OptionsMenuActionBack
+)
+
+const maxOptionsMenuOptions = 2
+
+// OptionsMenu lets the player change the settings of the game.
+type OptionsMenu struct {
+	selectedOption int32
+
+	themes []palette.Theme
+	theme  int
+}
+
+// NewOptionsMenu returns an OptionsMenu offering the given themes, where the first one is the current theme.
+func NewOptionsMenu(themes []palette.Theme) *OptionsMenu {
+	return &OptionsMenu{themes: themes}
+}
+
+// Render the options menu on the provided console.
+func (m *OptionsMenu) Render(console *console.MatrixConsole) {
+	fc, bc := palette.Get(palette.MenuText), palette.Get(palette.MenuBackground)
+	fcSelected, bcSelected := palette.Get(palette.MenuSelectedText), palette.Get(palette.MenuSelectedBackground)
+
+	options := []string{
+		fmt.Sprintf("Theme: < %s >", m.themes[m.theme].Name),
+		"Back",
+	}
+
+	console.Clear()
+	putString(console, 2, 1, "Options", fc, bc)
+	y := int32(4)
+	for n, line := range options {
+		if int32(n) == m.selectedOption {
+			putString(console, 2, y, line, fcSelected, bcSelected)
+		} else {
+			putString(console, 2, y, line, fc, bc)
+		}
+		y += 2
+	}
+	putString(console, 2, y+1, "Left/Right: change the theme", fc, bc)
+	putString(console, 2, y+2, "Esc: back to the main menu", fc, bc)
+}
+
+// putString writes s into the cells starting at x, y.
+func putString(c *console.MatrixConsole, x, y int32, s string, foregroundColor, backgroundColor utils.ColorRGBA) {
+	for _, r := range s {
+		c.PutCharColor(x, y, string(r), foregroundColor, backgroundColor)
+		x++
+	}
+}
+
+// MoveCursor moves the cursor of the currently selected item.
+// Moving left or right on the theme selects the previous or next theme and applies it.
+func (m *OptionsMenu) MoveCursor(dx, dy int32) {
+	m.selectedOption += dy
+	if m.selectedOption >= maxOptionsMenuOptions {
+		m.selectedOption = 0
+	}
+	if m.selectedOption < 0 {
+		m.selectedOption = maxOptionsMenuOptions - 1
+	}
+	if dx != 0 && m.selectedOption == 0 {
+		m.cycleTheme(int(dx))
+	}
+}
+
+// Select selects the currently activated cursor element.
+func (m *OptionsMenu) Select() OptionsMenuActionType {
+	switch m.selectedOption {
+	case 0:
+		m.cycleTheme(1)
+		return OptionsMenuActionNextTheme
+	case 1:
+		return OptionsMenuActionBack
+	default:
+		return OptionsMenuActionUnknown
+	}
+}
+
+// cycleTheme applies the theme delta entries after the current one.
+func (m *OptionsMenu) cycleTheme(delta int) {
+	m.theme = (m.theme + delta + len(m.themes)) % len(m.themes)
+	palette.SetTheme(m.themes[m.theme])
+}
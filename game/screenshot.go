@@ -0,0 +1,76 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"time"
+
+	"github.com/torlenor/asciiventure/console"
+)
+
+// screenshotFileName returns the name of a new screenshot file based on the current time.
+func screenshotFileName() string {
+	return fmt.Sprintf("screenshot_%s.png", time.Now().Format("20060102_150405"))
+}
+
+// writeScreenshot saves the frame drawn so far as a PNG file. It has to be called before the frame is presented.
+func (g *Game) writeScreenshot(path string) error {
+	s, ok := g.backend.(console.Screenshotter)
+	if !ok {
+		return fmt.Errorf("Screenshots are not supported by this backend")
+	}
+	img, err := s.Screenshot()
+	if err != nil {
+		return err
+	}
+	return writePNG(path, img)
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Unable to create file: %s", err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("Unable to encode PNG: %s", err)
+	}
+	return nil
+}
+
+// saveScreenshot saves the frame drawn so far and reports the result in the log pane.
+func (g *Game) saveScreenshot() {
+	path := screenshotFileName()
+	if err := g.writeScreenshot(path); err != nil {
+		log.Printf("Error taking screenshot: %s", err)
+		g.ui.AddLogEntry(fmt.Sprintf("Unable to take screenshot: %s.", err))
+		return
+	}
+	g.ui.AddLogEntry(fmt.Sprintf("Screenshot saved to %s.", path))
+}
+
+// Snapshot plays the given number of turns in which the player waits and
+// renders the resulting frame into a PNG file at path.
+// Use it with BackendFramebuffer and a fixed seed to get reproducible images.
+func (g *Game) Snapshot(path string, turns int) error {
+	g.gameInProgress = true
+	g.gameState = playersTurn
+	for i := 0; i < turns && g.gameState == playersTurn; i++ {
+		g.nextStep = true
+		g.timestep()
+	}
+	// Show the final state instead of a frame of the effects triggered by the last turn
+	for g.effects.Running() {
+		g.effects.Advance()
+	}
+	g.camera.Follow(g.player.Position.Current)
+	g.camera.Snap()
+
+	g.render()
+	err := g.writeScreenshot(path)
+	g.backend.Present()
+	return err
+}
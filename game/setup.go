@@ -1,95 +1,120 @@
 package game
 
 import (
+	"fmt"
 	"log"
 	"math/rand"
-	"runtime"
 	"time"
 
+	"github.com/torlenor/asciiventure/camera"
+	"github.com/torlenor/asciiventure/components"
 	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/framebuffer"
 	"github.com/torlenor/asciiventure/gamemap"
 	"github.com/torlenor/asciiventure/renderers"
-	"github.com/veandco/go-sdl2/sdl"
-	"github.com/veandco/go-sdl2/ttf"
+	"github.com/torlenor/asciiventure/terminal"
+	"github.com/torlenor/asciiventure/ui"
 )
 
-func (g *Game) setupWindow() {
-	err := ttf.Init()
-	if err != nil {
-		log.Fatalf("Failed to initialize ttf: %s", err)
-	}
-
-	if g.defaultFont, err = ttf.OpenFont(fontPath, fontSize); err != nil {
-		log.Fatalf("Failed to load font '%s': %s", fontPath, err)
-	}
-
-	err = sdl.Init(sdl.INIT_VIDEO)
-	if err != nil {
-		log.Fatalf("Failed to initialize sdl: %s", err)
-	}
+const (
+	// mainMenuWidth and mainMenuHeight are the number of cells of the main menu console
+	mainMenuWidth  = 54
+	mainMenuHeight = 42
+)
 
-	if g.fullscreen {
-		g.window, err = sdl.CreateWindow(windowName, 0,
-			0, 0, 0, sdl.WINDOW_SHOWN|sdl.WINDOW_FULLSCREEN_DESKTOP)
-	} else {
-		g.window, err = sdl.CreateWindow(windowName, sdl.WINDOWPOS_CENTERED,
-			sdl.WINDOWPOS_CENTERED, int32(g.screenWidth), int32(g.screenHeight), sdl.WINDOW_SHOWN)
+func (g *Game) setupBackend(backend string) error {
+	var err error
+	switch backend {
+	case BackendSDL:
+		g.backend, err = renderers.NewSDLBackend(windowName, g.screenWidth, g.screenHeight, g.fullscreen)
+	case BackendTerminal:
+		g.backend, err = terminal.New()
+	case BackendFramebuffer:
+		g.backend = framebuffer.New(int32(g.screenWidth), int32(g.screenHeight))
+	default:
+		return fmt.Errorf("Unknown backend '%s'", backend)
 	}
 	if err != nil {
-		log.Fatalf("Failed to create window: %s", err)
+		return fmt.Errorf("Failed to initialize backend '%s': %s", backend, err)
 	}
 
-	sdl.SetHint(sdl.HINT_RENDER_SCALE_QUALITY, "1")
-
-	if runtime.GOOS == "windows" {
-		sdl.SetHint(sdl.HINT_RENDER_DRIVER, "opengl")
-	}
+	w, h := g.backend.Size()
+	g.screenWidth = int(w)
+	g.screenHeight = int(h)
+	return nil
 }
 
-func (g *Game) setupRenderer() {
-	renderer, err := sdl.CreateRenderer(g.window, -1, sdl.RENDERER_ACCELERATED)
+func (g *Game) setupUI() error {
+	r, err := g.backend.GlyphRenderer(g.tilesets.UI)
 	if err != nil {
-		log.Fatalf("Failed to create renderer: %s", err)
+		return err
+	}
+	g.ui = ui.New(r)
+	g.ui.SetKeyHints(keyHints)
+	g.ui.OnInventorySelect(func(i int) {
+		g.performPlayerAction(components.ActionTypeUseItem, i)
+		g.nextStep = true
+	})
+	if r, err := g.backend.GlyphRenderer(g.tilesets.Minimap); err != nil {
+		log.Printf("Minimap disabled: %s", err)
+	} else {
+		scaler, _ := g.backend.(console.Scaler)
+		g.ui.SetupMinimap(r, scaler, minimapScale)
+	}
+	g.ui.SetScreenDimensions(g.screenWidth, g.screenHeight)
+	if m, ok := g.backend.(console.MinimumSizer); ok {
+		m.SetMinimumSize(g.ui.MinimumSize())
 	}
-	g.renderer = renderers.NewRenderer(renderer)
+	return nil
 }
 
-func (g *Game) setupConsoles() {
-	tileset, err := console.NewFontTileset(g.renderer, "./assets/textures/consolas12x12_gs_tc.png")
+func (g *Game) setupConsoles() error {
+	r, err := g.backend.GlyphRenderer(g.tilesets.Map)
 	if err != nil {
-		log.Fatalf("%s", err)
+		return err
 	}
-	availableWidth := int32(g.screenWidth - g.screenWidth/5)
-	availableHeight := int32(g.screenHeight - g.screenHeight/6 - 2*16)
-	g.consoleMap = console.NewMatrixConsole(g.renderer, tileset, availableWidth, availableHeight, availableWidth/tileset.GetCharWidth(), availableHeight/tileset.GetCharHeight())
-	g.consoleMap.SetOffset(0, int32(g.screenHeight/6))
+	g.camera = camera.New(camera.ModeCentered, cameraDeadzoneX, cameraDeadzoneY)
+	g.consoleMapASCII = g.newMapConsole(r)
+	g.setMapConsole(g.consoleMapASCII)
 
-	availableWidth = int32(g.screenWidth)
-	availableHeight = int32(g.screenHeight)
-	tilesetMainMenu, err := console.NewFontTileset(g.renderer, "./assets/textures/consolas6x12_gs_tc.png")
+	rMainMenu, err := g.backend.GlyphRenderer(g.tilesets.MainMenu)
 	if err != nil {
-		log.Fatalf("%s", err)
+		return err
 	}
-	g.consoleMainMenu = console.NewMatrixConsole(g.renderer, tilesetMainMenu, availableWidth, availableHeight, 54, 42)
+	g.consoleMainMenu = console.NewMatrixConsole(rMainMenu, 0, 0, 0, 0)
+	g.fitMainMenuConsole()
+	return nil
 }
 
-func (g *Game) setupGame() {
-	rand.Seed(time.Now().UnixNano())
-	g.createGlyphTexture()
+func (g *Game) setupGame() error {
+	if g.seed == 0 {
+		g.seed = time.Now().UnixNano()
+	}
+	rand.Seed(g.seed)
+	if err := g.loadBehaviours(); err != nil {
+		return err
+	}
+	if err := g.loadFactions(); err != nil {
+		return err
+	}
 	g.createPlayer()
 	g.loadedGameMaps = []*gamemap.GameMap{}
 	for i := 0; i < 3; i++ {
-		randomMap := gamemap.NewRandomMap(10, 6, 20, 100, 60, g.glyphTexture)
+		randomMap := gamemap.NewRandomMap(10, 6, 20, 100, 60)
 		g.loadedGameMaps = append(g.loadedGameMaps, &randomMap)
 	}
-	g.loadGameMapsFromDirectory("./assets/rooms")
+	if err := g.loadGameMapsFromDirectory("./assets/rooms"); err != nil {
+		return err
+	}
 	g.selectGameMap(1)
 
 	g.updateUI()
 
 	g.consoleMap.Clear()
-	g.currentGameMap.Render(g.consoleMap, g.player.FoV, g.player, g.entities, int32(g.renderer.OriginX), int32(g.renderer.OriginY))
+	offsetX, offsetY := g.camera.Offset()
+	g.currentGameMap.Render(g.consoleMap, g.player.FoV, g.lightMap, g.effects.Opacity, g.glyphs, g.entities, offsetX, offsetY)
 
 	g.ui.AddLogEntry("Welcome to Lili's Quest.")
 	g.ui.AddLogEntry("You are a young cat out hunting for mice.")
+	return nil
 }
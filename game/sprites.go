@@ -0,0 +1,76 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/tileset"
+)
+
+// spriteMappingPath is the file assigning sprites to tiles and entities for the graphical tile mode.
+const spriteMappingPath = "./data/sprites.json"
+
+// newMapConsole returns a console for the map filling the map pane of the UI.
+func (g *Game) newMapConsole(r console.GlyphRenderer) *console.MatrixConsole {
+	c := console.NewMatrixConsole(r, 0, 0, 0, 0)
+	fitMapConsole(c, g.ui.MapPane())
+	return c
+}
+
+// setMapConsole shows the map in c from now on.
+func (g *Game) setMapConsole(c *console.MatrixConsole) {
+	pane := g.ui.MapPane()
+	g.consoleMap = c
+	g.consoleMap.SetOffset(int32(float32(pane.X)/g.renderScale), int32(float32(pane.Y)/g.renderScale))
+	g.updateCameraView()
+}
+
+// toggleSprites switches the map between the ASCII glyphs and the sprites of the graphical tile mode.
+// Everything without a sprite is still shown with its glyph.
+func (g *Game) toggleSprites() {
+	if g.glyphs != nil {
+		g.glyphs = nil
+		g.setMapConsole(g.consoleMapASCII)
+		g.ui.AddLogEntry("Tile mode: ASCII.")
+		return
+	}
+	if g.consoleMapSprites == nil {
+		if err := g.loadSprites(); err != nil {
+			log.Printf("Graphical tile mode not available: %s", err)
+			g.ui.AddLogEntry("Graphical tiles are not available.")
+			return
+		}
+	}
+	g.glyphs = g.sprites
+	g.setMapConsole(g.consoleMapSprites)
+	g.ui.AddLogEntry("Tile mode: sprites.")
+}
+
+// loadSprites loads the sprites of the graphical tile mode with the map tileset as fallback
+// and creates the console showing them.
+func (g *Game) loadSprites() error {
+	tr, ok := g.backend.(console.TilesetRenderer)
+	if !ok {
+		return fmt.Errorf("The backend does not support sprites")
+	}
+	m, err := tileset.ParseSpriteMapping(spriteMappingPath)
+	if err != nil {
+		return err
+	}
+	fallback, err := tileset.Load(g.tilesets.Map)
+	if err != nil {
+		return err
+	}
+	sprites, err := tileset.NewSprites(m, fallback)
+	if err != nil {
+		return err
+	}
+	r, err := tr.TilesetGlyphRenderer(sprites.Tileset)
+	if err != nil {
+		return err
+	}
+	g.sprites = sprites
+	g.consoleMapSprites = g.newMapConsole(r)
+	return nil
+}
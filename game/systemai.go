@@ -0,0 +1,95 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/torlenor/asciiventure/ai"
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/pathfinding"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	behavioursPath = "./data/behaviours"
+	factionsPath   = "./data/factions.json"
+	// defaultBehaviour is used for monsters without or with an unknown behaviour
+	defaultBehaviour = "default"
+)
+
+// aiWorld exposes the game to the behaviour trees.
+type aiWorld struct {
+	g *Game
+}
+
+func (w aiWorld) Entities() []*entity.Entity { return w.g.entities }
+
+func (w aiWorld) Relation(a, b *entity.Entity) components.Relation {
+	return w.g.relation(a, b)
+}
+
+func (w aiWorld) Leader(e *entity.Entity) *entity.Entity {
+	if e.Companion != nil && e.Companion.Recruited {
+		return w.g.player
+	}
+	return nil
+}
+
+func (w aiWorld) Distance(a utils.Vec2, b utils.Vec2) float64 {
+	return w.g.currentGameMap.Distance(a, b)
+}
+
+func (w aiWorld) Path(e *entity.Entity, goal utils.Vec2) []utils.Vec2 {
+	return pathfinding.DetermineAstarPath(w.Graph(e), w.g, e.Position.Current, goal)
+}
+
+func (w aiWorld) Graph(e *entity.Entity) pathfinding.Graph {
+	return pathfinding.WithCosts(w.g.currentGameMap, trapCosts{g: w.g, e: e})
+}
+
+func (w aiWorld) Obstacles() pathfinding.Obstacles { return w.g }
+
+func (w aiWorld) Walkable(p utils.Vec2) bool {
+	return w.g.currentGameMap.Empty(p) && w.g.blockingEntityAt(p) == nil
+}
+
+func (w aiWorld) Neighbors(p utils.Vec2) []utils.Vec2 {
+	return w.g.currentGameMap.Neighbors(p)
+}
+
+func (g *Game) loadBehaviours() error {
+	var err error
+	g.behaviours, err = ai.LoadBehaviours(behavioursPath)
+	if err != nil {
+		return fmt.Errorf("Error loading behaviours: %s", err)
+	}
+	return nil
+}
+
+func (g *Game) loadFactions() error {
+	var err error
+	g.factions, err = entity.ParseFactions(factionsPath)
+	if err != nil {
+		return fmt.Errorf("Error loading factions: %s", err)
+	}
+	return nil
+}
+
+// relation returns how entity a regards entity b. Entities without a faction are neutral.
+func (g *Game) relation(a, b *entity.Entity) components.Relation {
+	if a.Faction == nil || b.Faction == nil {
+		return components.RelationNeutral
+	}
+	return g.factions.Relation(a.Faction.Name, b.Faction.Name)
+}
+
+// decideMonsterMove runs the behaviour tree of the monster and returns the position it wants to move to.
+func (g *Game) decideMonsterMove(e *entity.Entity) (utils.Vec2, bool) {
+	tree, ok := g.behaviours[e.AI.Behaviour]
+	if !ok {
+		if tree, ok = g.behaviours[defaultBehaviour]; !ok {
+			return utils.Vec2{}, false
+		}
+	}
+	return ai.Decide(tree, e, aiWorld{g: g})
+}
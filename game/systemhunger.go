@@ -0,0 +1,44 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/torlenor/asciiventure/components"
+)
+
+const (
+	playerMaxSatiation = 1000
+	// starvationDamage is the damage an entity without any satiation left takes every turn.
+	starvationDamage = 1
+	// satiatedRegenerationInterval is the number of turns after which satiated entities regenerate an additional HP.
+	satiatedRegenerationInterval = 10
+)
+
+// hungerMessage returns the log message shown when the player enters the hunger state.
+func hungerMessage(s components.HungerState) string {
+	return [...]string{"You are full.", "You are no longer hungry.", "You are getting hungry.", "You are starving!"}[s]
+}
+
+// hungerSystem lets all entities with satiation get hungrier and starve.
+func (g *Game) hungerSystem() {
+	for _, e := range g.entities {
+		if e.Satiation == nil || e.IsDead != nil {
+			continue
+		}
+		previous := e.Satiation.State()
+		if e.Satiation.Current > 0 {
+			e.Satiation.Current--
+		} else if e.Health != nil {
+			e.Health.CurrentHP -= starvationDamage
+			g.showDamage(e, starvationDamage)
+			if e.Health.CurrentHP <= 0 {
+				g.ui.AddLogEntry(fmt.Sprintf("%s starved to death.", e.Name))
+				g.killEntity(e, nil)
+				continue
+			}
+		}
+		if current := e.Satiation.State(); current != previous && e == g.player {
+			g.ui.AddLogEntry(hungerMessage(current))
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/gamemap"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	// instabilityHPLoss is the amount of maximum HP lost by a ReducedHP side effect.
+	instabilityHPLoss = 5
+	// instabilityTeleportRadius is the radius of a RandomTeleport side effect.
+	instabilityTeleportRadius = 12
+	// hallucinationDuration is the number of turns a Hallucination side effect lasts.
+	hallucinationDuration = 20
+)
+
+// hallucinationGlyphs are the glyphs a hallucinating player sees instead of the real monsters.
+var hallucinationGlyphs = []string{"d", "m", "D", "g", "&", "T", "Z", "?"}
+
+// mutationEvent triggers a random side effect out of the side effects of all mutations of e.
+func (g *Game) mutationEvent(e *entity.Entity) {
+	sideEffects := e.Mutations.SideEffects()
+	if len(sideEffects) == 0 {
+		g.ui.AddLogEntry(fmt.Sprintf("%s feels queasy for a moment.", e.Name))
+		return
+	}
+
+	switch sideEffects[rand.Intn(len(sideEffects))] {
+	case components.MutationSideEffectReducedHP:
+		if e.Health == nil {
+			return
+		}
+		e.Health.HP = utils.MaxInt32(1, e.Health.HP-instabilityHPLoss)
+		e.Health.CurrentHP = utils.MinInt32(e.Health.CurrentHP, e.Health.HP)
+		g.ui.AddLogEntry(fmt.Sprintf("The mutations of %s are tearing at its body.", e.Name))
+	case components.MutationSideEffectRandomTeleport:
+		g.ui.AddLogEntry(fmt.Sprintf("The unstable genes of %s pull it through space.", e.Name))
+		g.teleport(e, instabilityTeleportRadius)
+	case components.MutationSideEffectHallucination:
+		e.Instability.HallucinationTurns = hallucinationDuration
+		g.ui.AddLogEntry(fmt.Sprintf("The world around %s starts to shift.", e.Name))
+	}
+}
+
+// instabilitySystem lets hallucinations wear off and decides what the player sees while hallucinating.
+func (g *Game) instabilitySystem() {
+	g.hallucinatedGlyphs = make(map[*entity.Entity]string)
+	if g.player.Instability == nil || g.player.Instability.HallucinationTurns <= 0 {
+		return
+	}
+
+	g.player.Instability.HallucinationTurns--
+	if g.player.Instability.HallucinationTurns == 0 {
+		g.ui.AddLogEntry("The world around you stops shifting.")
+		return
+	}
+
+	for _, e := range g.entities {
+		if e != g.player && e.AI != nil && e.IsDead == nil {
+			g.hallucinatedGlyphs[e] = hallucinationGlyphs[rand.Intn(len(hallucinationGlyphs))]
+		}
+	}
+}
+
+// renderHallucinations draws the hallucinated glyphs over the visible monsters.
+func (g *Game) renderHallucinations() {
+	g.consoleMap.SetLayer(console.LayerActors)
+	for e, glyph := range g.hallucinatedGlyphs {
+		if e.Position == nil || !g.player.FoV.Visible(e.Position.Current) {
+			continue
+		}
+		rx, ry := g.currentGameMap.GetRenderCoordinatesFromPosition(e.Position.Current.X, e.Position.Current.Y)
+		g.consoleMap.PutCharColor(rx, ry, glyph, gamemap.AppearanceColor(e.Appearance), utils.ColorRGBA{})
+	}
+}
@@ -3,46 +3,96 @@ package game
 import (
 	"fmt"
 
+	"github.com/torlenor/asciiventure/ai"
 	"github.com/torlenor/asciiventure/components"
 	"github.com/torlenor/asciiventure/entity"
-	"github.com/torlenor/asciiventure/pathfinding"
 	"github.com/torlenor/asciiventure/utils"
 )
 
 func (g *Game) blocked(p utils.Vec2) (*entity.Entity, bool) {
 	for _, e := range g.entities {
-		if e.IsBlocking != nil && e.Position.Current.Equal(p) && g.player.FoV.Seen(p) {
+		if e.IsBlocking != nil && e.Position != nil && e.Position.Current.Equal(p) && g.player.FoV.Seen(p) {
 			return e, true
 		}
 	}
 	return nil, false
 }
 
-// killEntity declares the entity dead.
-func (g *Game) killEntity(e *entity.Entity) {
+// blockingEntityAt returns the blocking entity at the given position, regardless of what the player knows about it.
+func (g *Game) blockingEntityAt(p utils.Vec2) *entity.Entity {
+	for _, e := range g.entities {
+		if e.IsBlocking != nil && e.Position != nil && e.Position.Current.Equal(p) {
+			return e
+		}
+	}
+	return nil
+}
+
+// killEntity declares the entity dead. The killer gains experience for it,
+// it is nil if nobody is to blame, e.g., for starvation.
+func (g *Game) killEntity(e *entity.Entity, killer *entity.Entity) {
 	e.IsBlocking = nil
 	e.IsDead = &components.IsDead{}
 	g.ui.AddLogEntry(fmt.Sprintf("%s is dead.", e.Name))
+	if killer != nil {
+		g.gainExperience(killer, e)
+	}
+	if e == g.player {
+		g.gameState = gameOver
+	}
+}
+
+// removeEntity takes e off the map, e.g., when it falls down to another level.
+// The entity list is replaced instead of modified, so that loops over it can continue.
+func (g *Game) removeEntity(e *entity.Entity) {
+	entities := make([]*entity.Entity, 0, len(g.entities))
+	for _, other := range g.entities {
+		if other != e {
+			entities = append(entities, other)
+		}
+	}
+	g.entities = entities
+	e.Position = nil
+	e.IsBlocking = nil
+}
+
+// mayAttack returns true if e is willing to attack the target.
+// The player attacks everything which is not friendly, monsters only attack whom they are hostile to.
+func (g *Game) mayAttack(e *entity.Entity, target *entity.Entity) bool {
+	if e == g.player {
+		return g.relation(e, target) != components.RelationFriendly
+	}
+	return g.relation(e, target) == components.RelationHostile
 }
 
 func (g *Game) combat(e *entity.Entity, target *entity.Entity) {
 	// TODO: Combat shall be randomized based on the Power and Defense parameters provided by entity and target
 	results := e.Attack(target)
+	g.emitNoise(e, noiseVolumeCombat)
+	if target.AI != nil {
+		ai.Alert(target, e.Position.Current)
+	}
 	for _, result := range results {
-		if result.Type == entity.CombatResultTakeDamage {
+		switch result.Type {
+		case entity.CombatResultSneakAttack:
+			g.ui.AddLogEntry(fmt.Sprintf("%s catches %s unaware.", e.Name, target.Name))
+		case entity.CombatResultMessage:
+			g.ui.AddLogEntry(result.StringValue)
+		case entity.CombatResultTakeDamage:
 			target.Health.CurrentHP -= result.IntegerValue
+			g.showDamage(target, result.IntegerValue)
 			g.ui.AddLogEntry(fmt.Sprintf("%s scratches %s for %d hit points. %d/%d HP left.", e.Name, target.Name, result.IntegerValue, target.Health.CurrentHP, target.Health.HP))
 			if target.Health.CurrentHP <= 0 {
-				g.killEntity(target)
-				if target == g.player {
-					g.gameState = gameOver
-				}
+				g.killEntity(target, e)
 			}
 		}
 	}
 }
 
 func (g *Game) movementSystem(state gameState) {
+	if state == enemyTurn {
+		ai.BeginTurn(g.entities)
+	}
 	for _, e := range g.entities {
 		if (state == playersTurn && e != g.player) || (state == enemyTurn && e == g.player) || e.IsDead != nil || e.Position == nil {
 			continue
@@ -54,26 +104,47 @@ func (g *Game) movementSystem(state gameState) {
 			} else {
 				newPosition = g.player.TargetPosition
 			}
-		} else {
-			if e.AI != nil {
-				var path []utils.Vec2
-				if g.currentGameMap.Distance(g.player.Position.Current, e.Position.Initial) <= float64(e.AI.AttackRange) && g.currentGameMap.Distance(e.Position.Current, e.Position.Initial) <= float64(e.AI.AttackRangeUntil) {
-					path = pathfinding.DetermineAstarPath(g.currentGameMap, g, e.Position.Current, g.player.Position.Current)
-				} else {
-					path = pathfinding.DetermineAstarPath(g.currentGameMap, g, e.Position.Current, e.Position.Initial)
-				}
-				if len(path) > 0 {
-					newPosition = path[0]
-				}
+		} else if e.AI != nil {
+			var ok bool
+			if newPosition, ok = g.decideMonsterMove(e); !ok {
+				continue
 			}
 		}
 		if newPosition.Equal(e.Position.Current) {
 			continue
 		}
+		if e.Stuck != nil {
+			e.Stuck.Turns--
+			if e.Stuck.Turns <= 0 {
+				e.Stuck = nil
+			} else {
+				if e == g.player {
+					g.ui.AddLogEntry("You are stuck.")
+				}
+				continue
+			}
+		}
+		if e.Stealth != nil && e.Stealth.Sneaking {
+			// Sneaking entities only move every second turn
+			e.Stealth.Waited = !e.Stealth.Waited
+			if e.Stealth.Waited {
+				continue
+			}
+		}
 		roomEmpty := g.currentGameMap.Empty(newPosition)
 		blockingE, blocked := g.blocked(newPosition)
+		if roomEmpty && blocked && e == g.player && blockingE.Companion != nil && blockingE.Companion.Recruited {
+			// Swap places with companions instead of bumping into them
+			blockingE.MoveTo(e.Position.Current)
+			blocked = false
+		}
 		if roomEmpty && !blocked {
 			e.MoveTo(newPosition)
+			g.emitNoise(e, movementNoiseVolume(e))
+			g.triggerTrap(e, nil)
+			if e.Position == nil || e.IsDead != nil {
+				continue
+			}
 			if e == g.player {
 				if len(g.movementPath) > 0 {
 					g.movementPath = g.movementPath[1:]
@@ -83,7 +154,11 @@ func (g *Game) movementSystem(state gameState) {
 				}
 			}
 		} else if blocked {
-			if e.Combat != nil && blockingE.Combat != nil && !(e != g.player && blockingE != g.player) {
+			if e == g.player && blockingE.Companion != nil && !blockingE.Companion.Recruited {
+				g.recruit(blockingE)
+				g.movementPath = []utils.Vec2{}
+				e.TargetPosition = e.Position.Current
+			} else if e.Combat != nil && blockingE.Combat != nil && g.mayAttack(e, blockingE) {
 				g.combat(e, blockingE)
 				g.movementPath = []utils.Vec2{}
 				e.TargetPosition = e.Position.Current
@@ -0,0 +1,188 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	// pushRadius is the distance around the user in which Push affects entities.
+	pushRadius = 2
+	// pushCollisionDamage is the damage per remaining push distance an entity takes when it hits a wall or another entity.
+	pushCollisionDamage = 2
+)
+
+// maxAbilitySlots is the number of activatable mutations which can be bound to keys.
+const maxAbilitySlots = 4
+
+func (g *Game) mutationSystem() {
+	for _, e := range g.entities {
+		for i := range e.Mutations {
+			if e.Mutations[i].CooldownRemaining > 0 {
+				e.Mutations[i].CooldownRemaining--
+			}
+		}
+		if e.Actor != nil && e.Actor.NextAction == components.ActionTypeUseAbility {
+			g.useAbility(e, e.Actor.IntValue, e.Actor.Target)
+			e.Actor = nil
+		}
+	}
+}
+
+// useAbility activates the mutation in the given ability slot of e if it is ready and e has enough energy.
+func (g *Game) useAbility(e *entity.Entity, slot int, target utils.Vec2) {
+	abilities := e.Mutations.Abilities()
+	if slot < 0 || slot >= len(abilities) {
+		g.ui.AddLogEntry("There is no ability in that slot.")
+		return
+	}
+	m := &e.Mutations[abilities[slot]]
+	if m.CooldownRemaining > 0 {
+		g.ui.AddLogEntry(fmt.Sprintf("%s is not ready yet (%d turns).", m.Effect, m.CooldownRemaining))
+		return
+	}
+	if e.Energy == nil || e.Energy.Current < m.EnergyCost {
+		g.ui.AddLogEntry(fmt.Sprintf("Not enough mutagenic energy to use %s.", m.Effect))
+		return
+	}
+	e.Energy.Current -= m.EnergyCost
+	m.CooldownRemaining = m.Cooldown
+
+	switch m.Effect {
+	case components.MutationEffectPush:
+		g.push(e, m.Data)
+	case components.MutationEffectTeleport:
+		g.teleport(e, m.Data)
+	case components.MutationEffectTeleportOther:
+		g.teleportOther(e, target, m.Data)
+	default:
+		g.ui.AddLogEntry(fmt.Sprintf("%s cannot be activated.", m.Effect))
+	}
+}
+
+// push knocks back all visible monsters and items around e by distance tiles.
+func (g *Game) push(e *entity.Entity, distance int32) {
+	g.ui.AddLogEntry(fmt.Sprintf("%s releases a shock wave.", e.Name))
+	for _, target := range g.entities {
+		if target == e || target.Position == nil || target.IsDead != nil || (target.Combat == nil && target.Item == nil && target.Mutagen == nil) || target.Position.Current.Equal(e.Position.Current) {
+			continue
+		}
+		if g.currentGameMap.Distance(e.Position.Current, target.Position.Current) > pushRadius || !e.FoV.Visible(target.Position.Current) {
+			continue
+		}
+		g.knockBack(e, target, distance)
+	}
+}
+
+// knockBack moves the target away from the source along the line between them.
+// If the target hits a wall or a blocking entity it takes damage for the remaining distance.
+// Portals stop the movement without damage, as nothing shall be pushed onto them.
+// A living target springs the trap it lands on.
+func (g *Game) knockBack(source *entity.Entity, target *entity.Entity, distance int32) {
+	direction := utils.Vec2{
+		X: sign(target.Position.Current.X - source.Position.Current.X),
+		Y: sign(target.Position.Current.Y - source.Position.Current.Y),
+	}
+	moved := false
+	for i := int32(0); i < distance; i++ {
+		next := target.Position.Current.Add(direction)
+		if g.currentGameMap.IsPortal(next) {
+			break
+		}
+		if !g.currentGameMap.Empty(next) || (target.IsBlocking != nil && g.blockingEntityAt(next) != nil) {
+			if target.Health != nil {
+				dmg := (distance - i) * pushCollisionDamage
+				target.Health.CurrentHP -= dmg
+				g.showDamage(target, dmg)
+				g.ui.AddLogEntry(fmt.Sprintf("%s slams into an obstacle for %d hit points. %d/%d HP left.", target.Name, dmg, target.Health.CurrentHP, target.Health.HP))
+				if target.Health.CurrentHP <= 0 {
+					g.killEntity(target, source)
+				}
+			}
+			break
+		}
+		target.MoveTo(next)
+		moved = true
+	}
+	if moved && target.Health != nil && target.IsDead == nil {
+		g.triggerTrap(target, source)
+	}
+}
+
+// teleport moves e to a random reachable location within radius.
+func (g *Game) teleport(e *entity.Entity, radius int32) {
+	p, ok := g.findLandingSpot(e.Position.Current, radius)
+	if !ok {
+		g.ui.AddLogEntry(fmt.Sprintf("%s flickers, but nothing happens.", e.Name))
+		return
+	}
+	e.MoveTo(p)
+	e.TargetPosition = p
+	if e == g.player {
+		g.movementPath = []utils.Vec2{}
+	}
+	g.ui.AddLogEntry(fmt.Sprintf("%s teleports.", e.Name))
+}
+
+// teleportOther teleports the monster at the target position to a random reachable location within radius around it.
+func (g *Game) teleportOther(e *entity.Entity, target utils.Vec2, radius int32) {
+	if !e.FoV.Visible(target) {
+		g.ui.AddLogEntry("You cannot see the target.")
+		return
+	}
+	other := g.blockingEntityAt(target)
+	if other == nil || other == e || other.Combat == nil {
+		g.ui.AddLogEntry("There is nothing to teleport.")
+		return
+	}
+	g.showProjectile(e.Position.Current, target)
+	g.teleport(other, radius)
+	if !other.Position.Current.Equal(target) {
+		g.triggerTrap(other, e)
+	}
+}
+
+// findLandingSpot returns a random tile within radius of origin which can be reached by walking from origin
+// and where an entity may be placed.
+// Returns false as second value if there is no such tile.
+func (g *Game) findLandingSpot(origin utils.Vec2, radius int32) (utils.Vec2, bool) {
+	visited := map[utils.Vec2]bool{origin: true}
+	open := []utils.Vec2{origin}
+	var candidates []utils.Vec2
+	for len(open) > 0 {
+		current := open[0]
+		open = open[1:]
+		for _, n := range g.currentGameMap.Neighbors(current) {
+			if visited[n] || g.currentGameMap.Distance(origin, n) > float64(radius) {
+				continue
+			}
+			visited[n] = true
+			open = append(open, n)
+			if g.validLandingSpot(n) {
+				candidates = append(candidates, n)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return utils.Vec2{}, false
+	}
+	return candidates[rand.Intn(len(candidates))], true
+}
+
+// validLandingSpot returns true if an entity can be placed at p.
+func (g *Game) validLandingSpot(p utils.Vec2) bool {
+	return g.currentGameMap.Empty(p) && !g.currentGameMap.IsPortal(p) && g.blockingEntityAt(p) == nil
+}
+
+func sign(v int32) int32 {
+	if v > 0 {
+		return 1
+	} else if v < 0 {
+		return -1
+	}
+	return 0
+}
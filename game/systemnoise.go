@@ -0,0 +1,120 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/palette"
+	"github.com/torlenor/asciiventure/pathfinding"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	noiseVolumeMovement = 10
+	noiseVolumeCombat   = 20
+	// noiseWallAttenuation is the volume a noise loses for every wall between source and listener.
+	noiseWallAttenuation = 5
+	// noisePreciseVolume is the perceived volume above which the source of a noise can be located exactly.
+	noisePreciseVolume = 8
+
+	// playerHearingThreshold is the hearing threshold of the player without any mutations.
+	playerHearingThreshold = 10
+)
+
+// noise is a sound emitted by an entity during the current turn.
+type noise struct {
+	source   *entity.Entity
+	position utils.Vec2
+	volume   int32
+}
+
+// emitNoise lets the source entity make a noise with the given volume at its current position.
+func (g *Game) emitNoise(source *entity.Entity, volume int32) {
+	if source.Position == nil {
+		return
+	}
+	g.noises = append(g.noises, noise{source: source, position: source.Position.Current, volume: volume})
+}
+
+// movementNoiseVolume returns the volume of the noise the entity makes when moving.
+// Sneaking halves it.
+func movementNoiseVolume(e *entity.Entity) int32 {
+	if e.Stealth != nil && e.Stealth.Sneaking {
+		return noiseVolumeMovement / 2
+	}
+	return noiseVolumeMovement
+}
+
+// perceivedVolume returns the volume of the noise at the position of the listener.
+// It decreases with distance and every wall in between.
+func (g *Game) perceivedVolume(n noise, listener utils.Vec2) int32 {
+	volume := n.volume - int32(g.currentGameMap.Distance(n.position, listener))
+	for _, p := range pathfinding.DetermineStraightLinePath(n.position, listener) {
+		if g.currentGameMap.Opaque(p) {
+			volume -= noiseWallAttenuation
+		}
+	}
+	return volume
+}
+
+// hearingThreshold returns the lowest volume the entity is able to hear.
+// The second return value is false if the entity cannot hear at all.
+func hearingThreshold(e *entity.Entity) (int32, bool) {
+	if e.Hearing == nil {
+		return 0, false
+	}
+	return e.Hearing.Threshold - e.Mutations.GetData(components.MutationEffectHeightenedHearing), true
+}
+
+// noiseSystem distributes all noises of the current turn to the entities which can hear them.
+// Monsters investigate noises made by hostile entities and a player with heightened hearing
+// gets markers at the approximate positions of noises outside of the field of view.
+func (g *Game) noiseSystem() {
+	g.noiseMarkers = []utils.Vec2{}
+	for _, n := range g.noises {
+		for _, e := range g.entities {
+			if e == n.source || e.Position == nil || e.IsDead != nil {
+				continue
+			}
+			threshold, canHear := hearingThreshold(e)
+			if !canHear {
+				continue
+			}
+			volume := g.perceivedVolume(n, e.Position.Current)
+			if volume < threshold {
+				continue
+			}
+			if e == g.player {
+				if e.Mutations.Has(components.MutationEffectHeightenedHearing) && !e.FoV.Visible(n.position) {
+					g.noiseMarkers = append(g.noiseMarkers, approximateNoisePosition(n.position, volume))
+				}
+			} else if e.AI != nil && g.relation(e, n.source) == components.RelationHostile {
+				p := n.position
+				e.AI.NoiseTarget = &p
+			}
+		}
+	}
+	g.noises = []noise{}
+}
+
+// approximateNoisePosition returns the position where a listener thinks a noise came from.
+// Quiet noises cannot be located exactly.
+func approximateNoisePosition(p utils.Vec2, volume int32) utils.Vec2 {
+	if volume >= noisePreciseVolume {
+		return p
+	}
+	return p.Add(utils.Vec2{X: rand.Int31n(3) - 1, Y: rand.Int31n(3) - 1})
+}
+
+func (g *Game) renderNoiseMarkers() {
+	g.consoleMap.SetLayer(console.LayerEffects)
+	for _, p := range g.noiseMarkers {
+		if g.player.FoV.Visible(p) {
+			continue
+		}
+		rx, ry := g.currentGameMap.GetRenderCoordinatesFromPosition(p.X, p.Y)
+		g.consoleMap.PutCharColor(rx, ry, "?", palette.Get(palette.NoiseMarker), utils.ColorRGBA{})
+	}
+}
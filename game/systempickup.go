@@ -10,6 +10,13 @@ func (g *Game) pickupSystem() {
 	for _, e := range g.entities {
 		if e.Actor != nil && e.Actor.NextAction == components.ActionTypeInteract {
 			for _, target := range g.entities {
+				if target != nil && target.IsDead != nil && target.Edible != nil && target.Position != nil && target.Position.Current.Equal(e.Position.Current) {
+					for _, r := range e.Eat(target) {
+						if r.Type == entity.ActionResultMessage {
+							g.ui.AddLogEntry(r.StringValue)
+						}
+					}
+				}
 				if target != nil && (target.Item != nil || target.Mutagen != nil) && target.Position != nil && target.Position.Current.Equal(e.Position.Current) {
 					if target.Item != nil {
 						result := e.PickUpItem(target)
@@ -23,13 +30,21 @@ func (g *Game) pickupSystem() {
 					}
 					if target.Mutagen != nil {
 						result := e.ConsumeMutation(target)
+						slotsFull := false
 						for _, r := range result {
 							switch r.Type {
 							case entity.ActionResultMutationConsumed:
+							case entity.ActionResultMutationSlotsFull:
+								slotsFull = true
+							case entity.ActionResultInstabilityEvent:
+								g.mutationEvent(e)
 							case entity.ActionResultMessage:
 								g.ui.AddLogEntry(r.StringValue)
 							}
 						}
+						if slotsFull && e == g.player {
+							g.promptMutationReplacement(target)
+						}
 					}
 				}
 			}
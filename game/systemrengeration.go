@@ -1,14 +1,40 @@
 package game
 
+import (
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// healthRegeneration returns the HP the entity regenerates in the current turn.
+// Hungry entities do not regenerate, satiated ones regenerate faster.
+func (g *Game) healthRegeneration(e *entity.Entity) int32 {
+	regeneration := e.Health.Regeneration
+	if e.Satiation != nil {
+		switch e.Satiation.State() {
+		case components.HungerStateSatiated:
+			if g.time%satiatedRegenerationInterval == 0 {
+				regeneration++
+			}
+		case components.HungerStateHungry, components.HungerStateStarving:
+			regeneration = 0
+		}
+	}
+	return regeneration
+}
+
 func (g *Game) regenerationSystem() {
 	for _, e := range g.entities {
-		if e.Health != nil && e.IsDead == nil && e.Health.Regeneration != 0 && e.Health.CurrentHP < e.Health.HP {
-			e.Health.CurrentHP += e.Health.Regeneration
+		if e.Health != nil && e.IsDead == nil && e.Health.CurrentHP < e.Health.HP {
+			e.Health.CurrentHP += g.healthRegeneration(e)
 			if e.Health.CurrentHP > e.Health.HP {
 				e.Health.CurrentHP = e.Health.HP
 			} else if e.Health.CurrentHP < 0 {
-				g.killEntity(e)
+				g.killEntity(e, nil)
 			}
 		}
+		if e.Energy != nil && e.IsDead == nil && e.Energy.Current < e.Energy.Max {
+			e.Energy.Current = utils.MinInt32(e.Energy.Current+e.Energy.Regeneration, e.Energy.Max)
+		}
 	}
 }
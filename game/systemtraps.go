@@ -0,0 +1,153 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/entity"
+	"github.com/torlenor/asciiventure/gamemap"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	// passiveDetectionRadius is the distance up to which the player can notice hidden traps without searching.
+	passiveDetectionRadius = 2
+	// passiveDetectionChance is the chance in percent per point of perception to notice a hidden trap in a turn.
+	passiveDetectionChance = 5
+	searchRadius           = 2
+	// searchBaseChance is the chance in percent to find a hidden trap when searching, increased by perception.
+	searchBaseChance = 40
+	// searchPerceptionChance is the chance in percent per point of perception added when searching.
+	searchPerceptionChance = 15
+
+	snapTrapDamage = 5
+	pitFallDamage  = 2
+	glueTurns      = 4
+
+	// trapAvoidanceCost is the additional path finding cost for stepping on a known trap.
+	trapAvoidanceCost = 20
+)
+
+// trapSystem lets the player notice hidden traps passively or by searching.
+func (g *Game) trapSystem() {
+	if g.player.Position == nil || g.player.IsDead != nil || g.player.Perception == nil {
+		return
+	}
+	chance := g.player.Perception.Value * passiveDetectionChance
+	radius := int32(passiveDetectionRadius)
+	if g.player.Actor != nil && g.player.Actor.NextAction == components.ActionTypeSearch {
+		g.player.Actor = nil
+		chance = searchBaseChance + g.player.Perception.Value*searchPerceptionChance
+		radius = searchRadius
+		g.ui.AddLogEntry("You search your surroundings.")
+	}
+	for _, p := range g.currentGameMap.HiddenTraps(g.player.Position.Current, radius) {
+		if g.player.FoV.Visible(p) && rand.Int31n(100) < chance {
+			g.currentGameMap.DiscoverTrap(p)
+			trap, _ := g.currentGameMap.TrapAt(p)
+			g.ui.AddLogEntry(fmt.Sprintf("You notice a %s.", trap))
+		}
+	}
+}
+
+// triggerTrap springs the trap at the position of e, if there is one.
+// The cause is the entity which put e there, e.g., by pushing it, and nil if e walked there itself.
+func (g *Game) triggerTrap(e *entity.Entity, cause *entity.Entity) {
+	p := e.Position.Current
+	trap, _ := g.currentGameMap.TrapAt(p)
+	if trap == gamemap.TrapTypeNone {
+		return
+	}
+	visible := g.player.FoV.Visible(p)
+	if visible {
+		g.currentGameMap.DiscoverTrap(p)
+	}
+	g.learnTrap(e, p)
+	for _, other := range g.entities {
+		if other != g.player && other.AI != nil && other.IsDead == nil && other.FoV.Visible(p) {
+			g.learnTrap(other, p)
+		}
+	}
+
+	switch trap {
+	case gamemap.TrapTypeSnap:
+		g.currentGameMap.RemoveTrap(p)
+		g.logIfVisible(visible, fmt.Sprintf("A snap trap closes on %s for %d hit points.", e.Name, snapTrapDamage))
+		g.damage(e, snapTrapDamage, cause)
+	case gamemap.TrapTypeGlue:
+		e.Stuck = &components.Stuck{Turns: glueTurns}
+		g.logIfVisible(visible, fmt.Sprintf("%s is stuck in glue.", e.Name))
+	case gamemap.TrapTypePit:
+		g.logIfVisible(visible, fmt.Sprintf("%s falls into a pit.", e.Name))
+		if e == g.player {
+			g.damage(e, pitFallDamage, cause)
+			g.pendingMapChange = true
+		} else {
+			g.removeEntity(e)
+		}
+	}
+}
+
+// learnTrap lets a monster remember the trap at p. The player learns about traps by discovering them.
+func (g *Game) learnTrap(e *entity.Entity, p utils.Vec2) {
+	if e == g.player {
+		return
+	}
+	if e.KnownTraps == nil {
+		e.KnownTraps = components.NewKnownTraps()
+	}
+	e.KnownTraps.Learn(p)
+}
+
+// knowsTrap returns true if there is a trap at p and e knows about it.
+func (g *Game) knowsTrap(e *entity.Entity, p utils.Vec2) bool {
+	trap, discovered := g.currentGameMap.TrapAt(p)
+	if trap == gamemap.TrapTypeNone {
+		return false
+	}
+	if e == g.player {
+		return discovered
+	}
+	return e.KnownTraps != nil && e.KnownTraps.Knows(p)
+}
+
+// trapCosts lets the path finding of an entity avoid the traps it knows about.
+type trapCosts struct {
+	g *Game
+	e *entity.Entity
+}
+
+func (c trapCosts) Cost(p utils.Vec2) float64 {
+	if c.g.knowsTrap(c.e, p) {
+		return trapAvoidanceCost
+	}
+	return 0
+}
+
+// damage reduces the HP of e and kills it if none are left. The attacker may be nil, see killEntity.
+func (g *Game) damage(e *entity.Entity, dmg int32, attacker *entity.Entity) {
+	if e.Health == nil {
+		return
+	}
+	e.Health.CurrentHP -= dmg
+	g.showDamage(e, dmg)
+	if e.Health.CurrentHP <= 0 {
+		g.killEntity(e, attacker)
+	}
+}
+
+func (g *Game) logIfVisible(visible bool, text string) {
+	if visible {
+		g.ui.AddLogEntry(text)
+	}
+}
+
+// trapDescription returns the status bar text for a discovered trap at p.
+func (g *Game) trapDescription(p utils.Vec2) (string, bool) {
+	trap, discovered := g.currentGameMap.TrapAt(p)
+	if trap == gamemap.TrapTypeNone || !discovered {
+		return "", false
+	}
+	return fmt.Sprintf("%s: %s", trap, trap.Description()), true
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/torlenor/asciiventure/tileset"
+)
+
+// tilesetsPath is the file selecting the tilesets of the consoles.
+const tilesetsPath = "./data/tilesets.json"
+
+// tilesetConfig holds the tileset of every console. The minimap tileset should have square cells,
+// as the minimap is drawn with minimapScale.
+type tilesetConfig struct {
+	Map      tileset.Config `json:"Map"`
+	UI       tileset.Config `json:"UI"`
+	MainMenu tileset.Config `json:"MainMenu"`
+	Minimap  tileset.Config `json:"Minimap"`
+}
+
+// defaultTilesets are used for the consoles which are not configured in tilesetsPath.
+var defaultTilesets = tilesetConfig{
+	Map:      tileset.Config{Format: tileset.FormatLibtcod, Path: "./assets/textures/consolas12x12_gs_tc.png"},
+	UI:       tileset.Config{Format: tileset.FormatLibtcod, Path: "./assets/textures/consolas6x12_gs_tc.png"},
+	MainMenu: tileset.Config{Format: tileset.FormatLibtcod, Path: "./assets/textures/consolas6x12_gs_tc.png"},
+	Minimap:  tileset.Config{Format: tileset.FormatLibtcod, Path: "./assets/textures/terminal10x10_gs_tc.png"},
+}
+
+// loadTilesets reads the tileset configuration from path. The defaults are used if the file does not exist.
+func loadTilesets(path string) (tilesetConfig, error) {
+	c := defaultTilesets
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		log.Printf("No tileset configuration found at '%s', using the default tilesets", path)
+		return c, nil
+	} else if err != nil {
+		return c, fmt.Errorf("Error reading tileset configuration: %s", err)
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("Error parsing tileset configuration '%s': %s", path, err)
+	}
+	return c, nil
+}
@@ -1,14 +1,39 @@
 package game
 
 import (
+	"fmt"
+
 	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/ui"
 	"github.com/torlenor/asciiventure/utils"
 )
 
+// keyHints are shown in the footer of the UI while the player moves around.
+var keyHints = []ui.KeyHint{
+	{Key: "g", Description: "interact"},
+	{Key: "Tab", Description: "panes"},
+	{Key: "m", Description: "minimap"},
+	{Key: "F2", Description: "tiles"},
+	{Key: "F3", Description: "top panel"},
+	{Key: "F4", Description: "side panel"},
+	{Key: "Esc", Description: "menu"},
+}
+
+// uiInputs translates the commands used to control the focused pane or dialog of the UI.
+var uiInputs = map[command]ui.Input{
+	CommandMoveN:    ui.InputUp,
+	CommandMoveS:    ui.InputDown,
+	CommandInteract: ui.InputSelect,
+	CommandQuit:     ui.InputCancel,
+}
+
 func (g *Game) updateUI() {
 	g.updateCharacterWindow()
 	g.updateInventoryPane()
 	g.updateMutationsPane()
+	g.updateAbilityBar()
+	g.updatePartyPane()
+	g.updateMinimap()
 }
 
 func (g *Game) updateStatusBar() {
@@ -18,13 +43,15 @@ func (g *Game) updateStatusBar() {
 			continue
 		}
 		if e.Position.Current.Equal(utils.Vec2{X: targetX, Y: targetY}) && e != g.player {
-			if e.IsDead != nil {
+			if e.IsDead != nil && e.Edible != nil {
+				g.ui.SetStatusBarText(e.Name + "(Dead): Eat with 'g'")
+			} else if e.IsDead != nil {
 				g.ui.SetStatusBarText(e.Name + "(Dead)")
 			} else {
 				if e.Item != nil {
 					g.ui.SetStatusBarText(e.Name + ": Pick up item with 'g'")
 				} else if e.Mutagen != nil {
-					g.ui.SetStatusBarText(e.Mutagen.String() + ": " + e.Mutagen.GetDescription())
+					g.ui.SetStatusBarText(fmt.Sprintf("%s: %s Instability %d.", e.Mutagen.String(), e.Mutagen.GetDescription(), e.Mutagen.Instability))
 				} else {
 					g.ui.SetStatusBarText(e.Name)
 				}
@@ -32,7 +59,9 @@ func (g *Game) updateStatusBar() {
 			return
 		}
 	}
-	if g.currentGameMap.IsPortal(utils.Vec2{X: targetX, Y: targetY}) {
+	if text, ok := g.trapDescription(utils.Vec2{X: targetX, Y: targetY}); ok {
+		g.ui.SetStatusBarText(text)
+	} else if g.currentGameMap.IsPortal(utils.Vec2{X: targetX, Y: targetY}) {
 		g.ui.SetStatusBarText("Stairs to next map. Press 'g' to use them.")
 	} else {
 		g.ui.SetStatusBarText("")
@@ -44,10 +73,45 @@ func (g *Game) updateMutationsPane() {
 	g.ui.UpdateMutationsPane(g.player.Mutations)
 }
 
+// abilityKeys are the keys bound to the ability slots in setupInput.
+var abilityKeys = [maxAbilitySlots]string{"z", "x", "c", "v"}
+
+func (g *Game) updateAbilityBar() {
+	var entries []string
+	for slot, i := range g.player.Mutations.Abilities() {
+		if slot >= maxAbilitySlots {
+			break
+		}
+		m := g.player.Mutations[i]
+		state := "ready"
+		if m.CooldownRemaining > 0 {
+			state = fmt.Sprintf("%d turns", m.CooldownRemaining)
+		}
+		entries = append(entries, fmt.Sprintf("[%s] %s: %d EP, %s", abilityKeys[slot], m.Effect, m.EnergyCost, state))
+	}
+	g.ui.UpdateAbilityBar(entries)
+}
+
 func (g *Game) updateInventoryPane() {
 	g.ui.UpdateInventoryPane(g.player.Inventory)
 }
 
 func (g *Game) updateCharacterWindow() {
-	g.ui.UpdateCharacterPane(g.time, g.player.Health.CurrentHP, g.player.Health.HP, g.player.Vision.Range+g.player.Mutations.GetData(components.MutationEffectIncreasedVision), g.player.Combat.Power, g.player.Combat.Defense)
+	p := g.player
+	g.ui.UpdateCharacterPane(ui.CharacterInfo{
+		Time:          g.time,
+		DayPhase:      g.currentDayPhase().String(),
+		Level:         p.Experience.Level,
+		XP:            p.Experience.Current,
+		NextLevelXP:   p.Experience.NextLevel(),
+		CurrentHP:     p.Health.CurrentHP,
+		TotalHP:       p.Health.HP,
+		CurrentEnergy: p.Energy.Current,
+		TotalEnergy:   p.Energy.Max,
+		Instability:   p.Instability.Current,
+		Hunger:        p.Satiation.State().String(),
+		Vision:        p.Vision.Range + p.Mutations.GetData(components.MutationEffectIncreasedVision),
+		Power:         p.Combat.Power,
+		Defense:       p.Combat.Defense,
+	})
 }
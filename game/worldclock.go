@@ -0,0 +1,87 @@
+package game
+
+import (
+	"github.com/torlenor/asciiventure/fov"
+)
+
+const (
+	// turnsPerDay is the number of game time steps a full day/night cycle takes.
+	turnsPerDay = 400
+
+	// indoorAmbientLight is the light level on indoor maps independent of the time of day.
+	indoorAmbientLight = 0.1
+
+	// baseDarkVisionRange is the distance up to which entities can see unlit tiles.
+	baseDarkVisionRange = 2
+)
+
+// dayPhase is the phase of the day in the game world.
+type dayPhase int
+
+// List of dayPhases.
+const (
+	dayPhaseDawn dayPhase = iota
+	dayPhaseDay
+	dayPhaseDusk
+	dayPhaseNight
+)
+
+func (d dayPhase) String() string {
+	return [...]string{"Dawn", "Day", "Dusk", "Night"}[d]
+}
+
+// ambientLight returns the light level of the sun during the day phase.
+func (d dayPhase) ambientLight() float64 {
+	return [...]float64{0.5, 1.0, 0.5, 0.15}[d]
+}
+
+// message returns the log message shown when the day phase begins.
+func (d dayPhase) message() string {
+	return [...]string{"The sun rises.", "It is bright daylight now.", "The sun sets.", "Night falls."}[d]
+}
+
+// currentDayPhase returns the phase of the day derived from the game time.
+// The game starts at dawn.
+func (g *Game) currentDayPhase() dayPhase {
+	t := g.time % turnsPerDay
+	switch {
+	case t < turnsPerDay/8:
+		return dayPhaseDawn
+	case t < turnsPerDay/2:
+		return dayPhaseDay
+	case t < turnsPerDay/2+turnsPerDay/8:
+		return dayPhaseDusk
+	default:
+		return dayPhaseNight
+	}
+}
+
+// advanceTime moves the world clock one time step forward.
+func (g *Game) advanceTime() {
+	previous := g.currentDayPhase()
+	g.time++
+	if current := g.currentDayPhase(); current != previous && !g.currentGameMap.Indoor {
+		g.ui.AddLogEntry(current.message())
+	}
+}
+
+// updateLightMap recalculates the light levels of the current map from ambient light,
+// light emitting tiles and light emitting entities.
+func (g *Game) updateLightMap() {
+	ambient := g.currentDayPhase().ambientLight()
+	if g.currentGameMap.Indoor {
+		ambient = indoorAmbientLight
+	}
+	if g.lightMap == nil {
+		g.lightMap = fov.NewLightMap(ambient)
+	}
+	g.lightMap.Ambient = ambient
+
+	sources := g.currentGameMap.LightSources()
+	for _, e := range g.entities {
+		if e.LightSource != nil && e.Position != nil && e.IsDead == nil {
+			sources = append(sources, fov.LightSource{Position: e.Position.Current, Radius: e.LightSource.Radius, Intensity: e.LightSource.Intensity})
+		}
+	}
+	fov.UpdateLightMap(g.currentGameMap, g.lightMap, sources)
+}
@@ -8,24 +8,38 @@ import (
 	"math"
 	"strings"
 
-	"github.com/torlenor/asciiventure/assets"
 	"github.com/torlenor/asciiventure/entity"
-	"github.com/torlenor/asciiventure/renderers"
+	"github.com/torlenor/asciiventure/fov"
+	"github.com/torlenor/asciiventure/palette"
 	"github.com/torlenor/asciiventure/utils"
 )
 
 const (
 	emptyChar = "·"
+
+	// optionPrefix starts the option lines at the top of a room description, e.g., "::outdoor"
+	optionPrefix = "::"
 )
 
-var foregroundColorEmptyDot = utils.ColorRGBA{R: 220, G: 220, B: 220, A: 100}
-var foregroundColorWallVisible = utils.ColorRGBA{R: 200, G: 200, B: 200, A: 255}
-var foregroundColorNotVisible = utils.ColorRGBA{R: 80, G: 80, B: 100, A: 255}
-var foregroundColorEmptyDotNotVisible = utils.ColorRGBA{R: 40, G: 40, B: 40, A: 255}
+const (
+	foregroundColorEmptyDot           = palette.MapFloor
+	foregroundColorWallVisible        = palette.MapWall
+	foregroundColorNotVisible         = palette.MapNotVisible
+	foregroundColorEmptyDotNotVisible = palette.MapFloorNotVisible
+	foregroundColorPortal             = palette.MapPortal
+	foregroundColorCorpse             = palette.MapCorpse
+	foregroundColorLamp               = palette.MapLamp
+)
+
+const (
+	portalLightRadius    = 3
+	portalLightIntensity = 0.6
+	lampLightRadius      = 6
+	lampLightIntensity   = 0.9
+)
 
 // GameMap holds the data of a game map
 type GameMap struct {
-	T     *assets.GlyphTexture
 	Tiles map[int]map[int]Tile
 
 	Entities *[]*entity.Entity
@@ -33,31 +47,40 @@ type GameMap struct {
 	SpawnPoint     utils.Vec2
 	MapChangePoint utils.Vec2
 
-	notSeenGlyph renderers.Glyph
+	// Indoor maps are not lit by the sun
+	Indoor bool
 
 	currentOffsetX int32
 	currentOffsetY int32
 }
 
 // NewGameMapFromString constructs a room from the provided room description string
-func NewGameMapFromString(s string, glyphTexture *assets.GlyphTexture) (GameMap, error) {
+func NewGameMapFromString(s string) (GameMap, error) {
 	r := strings.NewReader(s)
-	return NewGameMapFromReader(r, glyphTexture)
+	return NewGameMapFromReader(r)
 }
 
-// NewGameMapFromReader constructs a room where the room description is read from the provided Reader
-func NewGameMapFromReader(r io.Reader, glyphTexture *assets.GlyphTexture) (GameMap, error) {
+// NewGameMapFromReader constructs a room where the room description is read from the provided Reader.
+// The description may start with option lines, see setOption. Rooms are outdoor by default.
+func NewGameMapFromReader(r io.Reader) (GameMap, error) {
+	room := GameMap{
+		Tiles: make(map[int]map[int]Tile),
+	}
 	b := bufio.NewReader(r)
 	lines := []string{}
 	for l, _, err := b.ReadLine(); err == nil; l, _, err = b.ReadLine() {
-		lines = append(lines, string(l))
+		line := string(l)
+		if len(lines) == 0 && strings.HasPrefix(line, optionPrefix) {
+			if err := room.setOption(strings.TrimPrefix(line, optionPrefix)); err != nil {
+				return GameMap{}, err
+			}
+			continue
+		}
+		lines = append(lines, line)
 	}
 	if len(lines) == 0 {
 		return GameMap{}, fmt.Errorf("Not a valid room description")
 	}
-	room := GameMap{
-		Tiles: make(map[int]map[int]Tile),
-	}
 	spawnPointSet := false
 	mapChangePointSet := false
 	for y, l := range lines {
@@ -84,7 +107,17 @@ func NewGameMapFromReader(r io.Reader, glyphTexture *assets.GlyphTexture) (GameM
 				c = " "
 			}
 
-			var foregroundColor utils.ColorRGBA
+			if trap := trapTypeFromChar(c); trap != TrapTypeNone {
+				room.Tiles[int(y)][int(x)] = Tile{Char: emptyChar, ForegroundColor: foregroundColorEmptyDot, Trap: trap}
+				continue
+			}
+
+			if c == "!" {
+				room.Tiles[int(y)][int(x)] = Tile{Char: c, Blocking: true, ForegroundColor: foregroundColorLamp, LightRadius: lampLightRadius, LightIntensity: lampLightIntensity}
+				continue
+			}
+
+			var foregroundColor palette.Name
 			if c == " " {
 				c = "·"
 				foregroundColor = foregroundColorEmptyDot
@@ -101,18 +134,30 @@ func NewGameMapFromReader(r io.Reader, glyphTexture *assets.GlyphTexture) (GameM
 		}
 	}
 
-	room.T = glyphTexture
-
-	room.notSeenGlyph, _ = room.T.Get("#")
-	room.notSeenGlyph.Color = utils.ColorRGB{
-		R: 20,
-		G: 20,
-		B: 20,
+	if mapChangePointSet {
+		portal := room.Tiles[int(room.MapChangePoint.Y)][int(room.MapChangePoint.X)]
+		portal.LightRadius = portalLightRadius
+		portal.LightIntensity = portalLightIntensity
+		room.Tiles[int(room.MapChangePoint.Y)][int(room.MapChangePoint.X)] = portal
 	}
 
 	return room, nil
 }
 
+// setOption applies an option line of a room description without its prefix.
+// Supported options are "indoor", which keeps the sun out of the room, and "outdoor".
+func (r *GameMap) setOption(option string) error {
+	switch strings.TrimSpace(option) {
+	case "indoor":
+		r.Indoor = true
+	case "outdoor":
+		r.Indoor = false
+	default:
+		return fmt.Errorf("Unknown room option '%s'", option)
+	}
+	return nil
+}
+
 // Distance returns the distance between two points on the map.
 func (r GameMap) Distance(a utils.Vec2, b utils.Vec2) float64 {
 	dx := b.X - a.X
@@ -184,6 +229,18 @@ func (r *GameMap) Neighbors(p utils.Vec2) []utils.Vec2 {
 	return neighbors
 }
 
+// LightSources returns all tiles of the map which emit light.
+func (r *GameMap) LightSources() (sources []fov.LightSource) {
+	for y, l := range r.Tiles {
+		for x, t := range l {
+			if t.LightRadius > 0 {
+				sources = append(sources, fov.LightSource{Position: utils.Vec2{X: int32(x), Y: int32(y)}, Radius: t.LightRadius, Intensity: t.LightIntensity})
+			}
+		}
+	}
+	return
+}
+
 // IsPortal returns true if the location is a portal to another map.
 func (r *GameMap) IsPortal(p utils.Vec2) bool {
 	if p.Equal(r.MapChangePoint) {
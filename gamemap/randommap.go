@@ -3,12 +3,14 @@ package gamemap
 import (
 	"math/rand"
 
-	"github.com/torlenor/asciiventure/assets"
 	"github.com/torlenor/asciiventure/utils"
 )
 
+// trapsPerRoom is the number of traps the generator tries to place per room.
+const trapsPerRoom = 2
+
 //NewRandomMap returns a random game map with the specified number of rooms and sizes.
-func NewRandomMap(maxRooms int, roomMinSize, roomMaxSize, mapWidth, mapHeight int, glyphTexture *assets.GlyphTexture) GameMap {
+func NewRandomMap(maxRooms int, roomMinSize, roomMaxSize, mapWidth, mapHeight int) GameMap {
 	var gameMap GameMap
 	gameMap.Tiles = make(map[int]map[int]Tile)
 
@@ -63,17 +65,12 @@ Loop:
 	gameMap.Tiles[int(mapChangeY)][int(mapChangeX)] = Tile{Char: "+",
 		Opaque:          false,
 		Blocking:        false,
-		ForegroundColor: utils.ColorRGBA{R: 255, G: 255, B: 0, A: 255},
+		ForegroundColor: foregroundColorPortal,
+		LightRadius:     portalLightRadius,
+		LightIntensity:  portalLightIntensity,
 	}
 
-	gameMap.T = glyphTexture
-
-	gameMap.notSeenGlyph, _ = gameMap.T.Get("#")
-	gameMap.notSeenGlyph.Color = utils.ColorRGB{
-		R: 20,
-		G: 20,
-		B: 20,
-	}
+	placeTraps(&gameMap, len(rooms)*trapsPerRoom)
 
 	return gameMap
 }
@@ -1,54 +1,133 @@
 package gamemap
 
 import (
+	"github.com/torlenor/asciiventure/components"
 	"github.com/torlenor/asciiventure/console"
 	"github.com/torlenor/asciiventure/entity"
 	"github.com/torlenor/asciiventure/fov"
+	"github.com/torlenor/asciiventure/palette"
 	"github.com/torlenor/asciiventure/utils"
 )
 
-// Render renders the current state of the room to the provided renderer.
-func (r *GameMap) Render(console *console.MatrixConsole, foV fov.FoVMap, player *entity.Entity, entities []*entity.Entity, offsetX, offsetY int32) {
-	cnx, cny := console.GetDimensions()
-	r.currentOffsetX = offsetX - int32(player.Position.Current.X) + cnx/2
-	r.currentOffsetY = offsetY - int32(player.Position.Current.Y) + cny/2
+// minRenderLight is the darkest a visible tile is rendered.
+const minRenderLight = 0.35
 
+// dim returns the color with its RGB values scaled according to the light level.
+func dim(c utils.ColorRGBA, light float64) utils.ColorRGBA {
+	if light < minRenderLight {
+		light = minRenderLight
+	} else if light > 1 {
+		light = 1
+	}
+	return utils.ColorRGBA{R: uint8(float64(c.R) * light), G: uint8(float64(c.G) * light), B: uint8(float64(c.B) * light), A: c.A}
+}
+
+// tint returns the color mixed with the tint color by the given amount between 0 and 1.
+func tint(c utils.ColorRGBA, t utils.ColorRGBA, amount float64) utils.ColorRGBA {
+	mix := func(a, b uint8) uint8 { return uint8(float64(a)*(1-amount) + float64(b)*amount) }
+	return utils.ColorRGBA{R: mix(c.R, t.R), G: mix(c.G, t.G), B: mix(c.B, t.B), A: c.A}
+}
+
+// AppearanceColor returns the color of the appearance in the current palette.
+func AppearanceColor(a *components.Appearance) utils.ColorRGBA {
+	return palette.Resolve(palette.Name(a.ColorName), a.Color)
+}
+
+// entityColor returns the color of the entity glyph tinted according to the alertness of monsters.
+func entityColor(e *entity.Entity) utils.ColorRGBA {
+	c := AppearanceColor(e.Appearance)
+	if e.AI == nil {
+		return c
+	}
+	switch e.AI.Alertness {
+	case components.AlertnessSuspicious:
+		return tint(c, palette.Get(palette.TintSuspicious), 0.5)
+	case components.AlertnessHunting:
+		return tint(c, palette.Get(palette.TintHunting), 0.5)
+	default:
+		return c
+	}
+}
+
+// transparent returns the color with its alpha value scaled by the opacity at p.
+func transparent(c utils.ColorRGBA, opacity func(utils.Vec2) float64, p utils.Vec2) utils.ColorRGBA {
+	if opacity == nil {
+		return c
+	}
+	c.A = uint8(float64(c.A) * opacity(p))
+	return c
+}
+
+// Glyphs decides which glyph is drawn for the char of a tile or an entity, e.g., to draw sprites instead.
+type Glyphs interface {
+	// Char returns the glyph for the char of a tile or an entity appearance.
+	Char(char string) string
+	// Entity returns the glyph for the entity with the given name and appearance char.
+	Entity(name string, char string) string
+}
+
+// Render renders the current state of the room to the provided console.
+// The offset is added to the map positions to get the cells of the console, see camera.Camera.Offset.
+// Visible tiles are dimmed according to their level in lightMap.
+// The colors of tiles and entities are made transparent according to opacity, if it is not nil.
+// The glyphs are chosen by glyphs, if it is not nil, otherwise the chars are drawn.
+func (r *GameMap) Render(c *console.MatrixConsole, foV fov.FoVMap, lightMap *fov.LightMap, opacity func(utils.Vec2) float64, glyphs Glyphs, entities []*entity.Entity, offsetX, offsetY int32) {
+	r.currentOffsetX = offsetX
+	r.currentOffsetY = offsetY
+
+	c.SetLayer(console.LayerTerrain)
 	for y, l := range r.Tiles {
 		for x, t := range l {
-			foregroundColor := t.ForegroundColor
+			foregroundColor := palette.Get(t.ForegroundColor)
 			p := utils.Vec2{X: int32(x), Y: int32(y)}
 			if !foV.Visible(p) && foV.Seen(p) {
 				if t.Char == "·" {
 					t.Char = " "
-					foregroundColor = foregroundColorEmptyDotNotVisible
+					foregroundColor = palette.Get(foregroundColorEmptyDotNotVisible)
 				} else {
-					foregroundColor = foregroundColorNotVisible
+					foregroundColor = palette.Get(foregroundColorNotVisible)
 				}
 			} else if !foV.Visible(p) {
 				continue
+			} else {
+				foregroundColor = dim(foregroundColor, lightMap.Get(p))
+			}
+			if t.Trap != TrapTypeNone && t.TrapDiscovered {
+				t.Char = t.Trap.char()
+				foregroundColor = palette.Get(foregroundColorTrap)
+				if !foV.Visible(p) {
+					foregroundColor = palette.Get(foregroundColorNotVisible)
+				}
 			}
 
-			console.PutCharColor(int32(x)+r.currentOffsetX, int32(y)+r.currentOffsetY, t.Char, foregroundColor, utils.ColorRGBA{})
+			if glyphs != nil {
+				t.Char = glyphs.Char(t.Char)
+			}
+			c.PutCharColor(int32(x)+r.currentOffsetX, int32(y)+r.currentOffsetY, t.Char, transparent(foregroundColor, opacity, p), utils.ColorRGBA{})
 		}
 	}
 
-	// TODO: Optimize rendering of entities on map so that we do not need three passes
 	for _, e := range entities {
-		if e.Position != nil && e.Appearance != nil && foV.Visible(e.Position.Current) && e.IsDead != nil {
-			console.PutCharColor(int32(e.Position.Current.X)+r.currentOffsetX, int32(e.Position.Current.Y)+r.currentOffsetY, "%", utils.ColorRGBA{R: 150, G: 150, B: 150, A: 255}, utils.ColorRGBA{})
-		}
-	}
-	for _, e := range entities {
-		if e.Position != nil && e.Appearance != nil && foV.Visible(e.Position.Current) && (e.Item != nil || e.Mutagen != nil) {
-			console.PutCharColor(int32(e.Position.Current.X)+r.currentOffsetX, int32(e.Position.Current.Y)+r.currentOffsetY, e.Appearance.Char, e.Appearance.Color, utils.ColorRGBA{})
-		}
-	}
-	for _, e := range entities {
-		if e.Position == nil || e.Appearance == nil || e.Item != nil || e.Mutagen != nil || e.IsDead != nil {
+		if e.Position == nil || e.Appearance == nil || !foV.Visible(e.Position.Current) {
 			continue
 		}
-		if foV.Visible(e.Position.Current) {
-			console.PutCharColor(int32(e.Position.Current.X)+r.currentOffsetX, int32(e.Position.Current.Y)+r.currentOffsetY, e.Appearance.Char, e.Appearance.Color, utils.ColorRGBA{})
+		x := int32(e.Position.Current.X) + r.currentOffsetX
+		y := int32(e.Position.Current.Y) + r.currentOffsetY
+		corpse, char := "%", e.Appearance.Char
+		if glyphs != nil {
+			corpse, char = glyphs.Char(corpse), glyphs.Entity(e.Name, char)
+		}
+		switch {
+		case e.IsDead != nil:
+			// Corpses replace the floor, so that items lying on them are still visible
+			c.SetLayer(console.LayerTerrain)
+			c.PutCharColor(x, y, corpse, transparent(palette.Get(foregroundColorCorpse), opacity, e.Position.Current), utils.ColorRGBA{})
+		case e.Item != nil || e.Mutagen != nil:
+			c.SetLayer(console.LayerItems)
+			c.PutCharColor(x, y, char, transparent(AppearanceColor(e.Appearance), opacity, e.Position.Current), utils.ColorRGBA{})
+		default:
+			c.SetLayer(console.LayerActors)
+			c.PutCharColor(x, y, char, transparent(entityColor(e), opacity, e.Position.Current), utils.ColorRGBA{})
 		}
 	}
 }
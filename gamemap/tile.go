@@ -1,14 +1,25 @@
 package gamemap
 
-import "github.com/torlenor/asciiventure/utils"
+import (
+	"github.com/torlenor/asciiventure/palette"
+	"github.com/torlenor/asciiventure/utils"
+)
 
 // Tile is one segment on a game map
 type Tile struct {
 	Char string
 
-	ForegroundColor utils.ColorRGBA
+	ForegroundColor palette.Name
 	BackgroundColor utils.ColorRGBA
 
 	Opaque   bool
 	Blocking bool
+
+	// LightRadius is the radius in which the tile emits light, 0 if it does not emit light
+	LightRadius    int32
+	LightIntensity float64
+
+	Trap TrapType
+	// TrapDiscovered is true if the player knows about the trap
+	TrapDiscovered bool
 }
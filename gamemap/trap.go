@@ -0,0 +1,106 @@
+package gamemap
+
+import (
+	"math/rand"
+
+	"github.com/torlenor/asciiventure/palette"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// TrapType is the kind of trap on a tile.
+type TrapType int
+
+// List of TrapTypes.
+const (
+	TrapTypeNone TrapType = iota
+	// TrapTypeSnap damages whoever steps on it and is used up afterwards
+	TrapTypeSnap
+	// TrapTypeGlue holds whoever steps on it in place for a few turns
+	TrapTypeGlue
+	// TrapTypePit lets whoever steps on it fall down to the next level
+	TrapTypePit
+)
+
+func (d TrapType) String() string {
+	return [...]string{"None", "Snap trap", "Glue trap", "Pit"}[d]
+}
+
+// Description returns a short description of the effect of the trap.
+func (d TrapType) Description() string {
+	return [...]string{"", "Snaps shut when stepped on.", "Sticks to the feet of whoever steps on it.", "A deep hole leading to the level below."}[d]
+}
+
+// char returns the glyph of the trap, which is also used in map files.
+func (d TrapType) char() string {
+	return [...]string{"", "^", "~", "O"}[d]
+}
+
+// trapTypeFromChar returns the TrapType for a glyph in a map file.
+func trapTypeFromChar(c string) TrapType {
+	for _, t := range []TrapType{TrapTypeSnap, TrapTypeGlue, TrapTypePit} {
+		if t.char() == c {
+			return t
+		}
+	}
+	return TrapTypeNone
+}
+
+const foregroundColorTrap = palette.MapTrap
+
+// TrapAt returns the trap at p and if it has been discovered.
+func (r *GameMap) TrapAt(p utils.Vec2) (TrapType, bool) {
+	t := r.Tiles[int(p.Y)][int(p.X)]
+	return t.Trap, t.TrapDiscovered
+}
+
+// DiscoverTrap marks the trap at p as discovered.
+func (r *GameMap) DiscoverTrap(p utils.Vec2) {
+	r.updateTile(p, func(t *Tile) { t.TrapDiscovered = t.Trap != TrapTypeNone })
+}
+
+// RemoveTrap removes the trap at p.
+func (r *GameMap) RemoveTrap(p utils.Vec2) {
+	r.updateTile(p, func(t *Tile) {
+		t.Trap = TrapTypeNone
+		t.TrapDiscovered = false
+	})
+}
+
+// HiddenTraps returns the positions of all traps which have not been discovered within radius around p.
+func (r *GameMap) HiddenTraps(p utils.Vec2, radius int32) (traps []utils.Vec2) {
+	for y := p.Y - radius; y <= p.Y+radius; y++ {
+		for x := p.X - radius; x <= p.X+radius; x++ {
+			t := r.Tiles[int(y)][int(x)]
+			if t.Trap != TrapTypeNone && !t.TrapDiscovered {
+				traps = append(traps, utils.Vec2{X: x, Y: y})
+			}
+		}
+	}
+	return
+}
+
+func (r *GameMap) updateTile(p utils.Vec2, f func(t *Tile)) {
+	row, ok := r.Tiles[int(p.Y)]
+	if !ok {
+		return
+	}
+	t, ok := row[int(p.X)]
+	if !ok {
+		return
+	}
+	f(&t)
+	row[int(p.X)] = t
+}
+
+// placeTraps puts count random hidden traps on empty tiles of the map, but not on the spawn and map change point.
+func placeTraps(gameMap *GameMap, count int) {
+	maxx, maxy := gameMap.Dimensions()
+	for i := 0; i < count; i++ {
+		p := utils.Vec2{X: rand.Int31n(maxx + 1), Y: rand.Int31n(maxy + 1)}
+		if !gameMap.Empty(p) || p.Equal(gameMap.SpawnPoint) || p.Equal(gameMap.MapChangePoint) {
+			continue
+		}
+		trap := TrapType(1 + rand.Intn(3))
+		gameMap.updateTile(p, func(t *Tile) { t.Trap = trap })
+	}
+}
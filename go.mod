@@ -2,4 +2,8 @@ module github.com/torlenor/asciiventure
 
 go 1.14
 
-require github.com/veandco/go-sdl2 v0.4.4
+require (
+	github.com/veandco/go-sdl2 v0.4.4
+	golang.org/x/image v0.18.0
+	golang.org/x/term v0.21.0
+)
@@ -0,0 +1,211 @@
+// Package palette holds the named colors of the game. Code and data reference colors by name,
+// so that the whole game changes its colors when a different theme is selected.
+package palette
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// Name identifies a color of the palette.
+type Name string
+
+// Names of the colors used by the game. Data files may reference additional names, e.g., for monsters.
+const (
+	MapFloor           Name = "MapFloor"
+	MapFloorNotVisible Name = "MapFloorNotVisible"
+	MapWall            Name = "MapWall"
+	MapNotVisible      Name = "MapNotVisible"
+	MapPortal          Name = "MapPortal"
+	MapCorpse          Name = "MapCorpse"
+	MapLamp            Name = "MapLamp"
+	MapTrap            Name = "MapTrap"
+
+	TintSuspicious Name = "TintSuspicious"
+	TintHunting    Name = "TintHunting"
+
+	Player      Name = "Player"
+	PathFree    Name = "PathFree"
+	PathBlocked Name = "PathBlocked"
+	MouseTile   Name = "MouseTile"
+	NoiseMarker Name = "NoiseMarker"
+
+	DamageFlash  Name = "DamageFlash"
+	DamageNumber Name = "DamageNumber"
+	Projectile   Name = "Projectile"
+	ImpactFlash  Name = "ImpactFlash"
+
+	MenuText               Name = "MenuText"
+	MenuBackground         Name = "MenuBackground"
+	MenuSelectedText       Name = "MenuSelectedText"
+	MenuSelectedBackground Name = "MenuSelectedBackground"
+
+	UIText               Name = "UIText"
+	UIBackground         Name = "UIBackground"
+	UIBorder             Name = "UIBorder"
+	UIFocusBorder        Name = "UIFocusBorder"
+	UISelectedText       Name = "UISelectedText"
+	UISelectedBackground Name = "UISelectedBackground"
+	UIKeyHint            Name = "UIKeyHint"
+	UIBarEmpty           Name = "UIBarEmpty"
+	UIBarHealth          Name = "UIBarHealth"
+	UIBarEnergy          Name = "UIBarEnergy"
+	UIBarExperience      Name = "UIBarExperience"
+
+	MinimapUnknown Name = "MinimapUnknown"
+	MinimapFloor   Name = "MinimapFloor"
+	MinimapWall    Name = "MinimapWall"
+	MinimapPortal  Name = "MinimapPortal"
+	MinimapPlayer  Name = "MinimapPlayer"
+)
+
+// Theme assigns colors to names.
+type Theme struct {
+	Name   string                   `json:"Name"`
+	Colors map[Name]utils.ColorRGBA `json:"Colors"`
+}
+
+// Default is the theme the game starts with. It defines every color, so other themes only have to
+// define the colors they change.
+var Default = Theme{
+	Name: "Default",
+	Colors: map[Name]utils.ColorRGBA{
+		MapFloor:           {R: 220, G: 220, B: 220, A: 100},
+		MapFloorNotVisible: {R: 40, G: 40, B: 40, A: 255},
+		MapWall:            {R: 200, G: 200, B: 200, A: 255},
+		MapNotVisible:      {R: 80, G: 80, B: 100, A: 255},
+		MapPortal:          {R: 255, G: 255, B: 0, A: 255},
+		MapCorpse:          {R: 150, G: 150, B: 150, A: 255},
+		MapLamp:            {R: 255, G: 220, B: 120, A: 255},
+		MapTrap:            {R: 255, G: 120, B: 40, A: 255},
+
+		TintSuspicious: {R: 255, G: 220, B: 0, A: 255},
+		TintHunting:    {R: 255, G: 40, B: 40, A: 255},
+
+		Player:      {R: 0, G: 128, B: 255, A: 255},
+		PathFree:    {R: 100, G: 100, B: 255, A: 64},
+		PathBlocked: {R: 255, G: 80, B: 80, A: 100},
+		MouseTile:   {R: 128, G: 128, B: 128, A: 120},
+		NoiseMarker: {R: 200, G: 200, B: 120, A: 140},
+
+		DamageFlash:  {R: 255, G: 0, B: 0, A: 160},
+		DamageNumber: {R: 255, G: 80, B: 80, A: 255},
+		Projectile:   {R: 180, G: 120, B: 255, A: 255},
+		ImpactFlash:  {R: 180, G: 120, B: 255, A: 160},
+
+		MenuText:               {R: 255, G: 255, B: 255, A: 255},
+		MenuBackground:         {},
+		MenuSelectedText:       {R: 0, G: 0, B: 0, A: 255},
+		MenuSelectedBackground: {R: 255, G: 255, B: 255, A: 255},
+
+		UIText:               {R: 255, G: 255, B: 255, A: 255},
+		UIBackground:         {A: 255},
+		UIBorder:             {R: 170, G: 170, B: 170, A: 255},
+		UIFocusBorder:        {R: 255, G: 215, B: 0, A: 255},
+		UISelectedText:       {R: 0, G: 0, B: 0, A: 255},
+		UISelectedBackground: {R: 200, G: 200, B: 200, A: 255},
+		UIKeyHint:            {R: 255, G: 215, B: 0, A: 255},
+		UIBarEmpty:           {R: 50, G: 50, B: 50, A: 255},
+		UIBarHealth:          {R: 180, G: 30, B: 30, A: 255},
+		UIBarEnergy:          {R: 40, G: 90, B: 200, A: 255},
+		UIBarExperience:      {R: 150, G: 120, B: 20, A: 255},
+
+		MinimapUnknown: {R: 0, G: 0, B: 0, A: 200},
+		MinimapFloor:   {R: 70, G: 70, B: 80, A: 255},
+		MinimapWall:    {R: 170, G: 170, B: 170, A: 255},
+		MinimapPortal:  {R: 255, G: 255, B: 0, A: 255},
+		MinimapPlayer:  {R: 0, G: 255, B: 0, A: 255},
+
+		"Dog":     {R: 255, G: 0, B: 0, A: 255},
+		"Kitten":  {R: 120, G: 180, B: 255, A: 255},
+		"Mouse":   {R: 200, G: 200, B: 200, A: 255},
+		"Item":    {R: 255, G: 255, B: 255, A: 255},
+		"Mutagen": {R: 100, G: 255, B: 100, A: 255},
+	},
+}
+
+// current is the theme in use.
+var current = Default
+
+// missing is the color of names which are not defined in any theme, so that they stand out.
+var missing = utils.ColorRGBA{R: 255, G: 0, B: 255, A: 255}
+
+// SetTheme makes t the theme in use.
+func SetTheme(t Theme) {
+	current = t
+}
+
+// Current returns the theme in use.
+func Current() Theme {
+	return current
+}
+
+// Get returns the color with the given name in the current theme. Colors the theme does not define
+// are taken from the Default theme.
+func Get(n Name) utils.ColorRGBA {
+	c, ok := Lookup(n)
+	if !ok {
+		return missing
+	}
+	return c
+}
+
+// Lookup returns the color with the given name in the current theme or the Default theme,
+// and false if none of them defines it.
+func Lookup(n Name) (utils.ColorRGBA, bool) {
+	if c, ok := current.Colors[n]; ok {
+		return c, true
+	}
+	c, ok := Default.Colors[n]
+	return c, ok
+}
+
+// Resolve returns the color with the given name, or fallback if the name is empty or not defined.
+func Resolve(n Name, fallback utils.ColorRGBA) utils.ColorRGBA {
+	if n == "" {
+		return fallback
+	}
+	if c, ok := Lookup(n); ok {
+		return c
+	}
+	return fallback
+}
+
+// ParseTheme reads a Theme from the JSON file at filename.
+func ParseTheme(filename string) (Theme, error) {
+	var t Theme
+	file, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return t, fmt.Errorf("Error reading theme JSON file %s: %s", filename, err)
+	}
+	if err := json.Unmarshal(file, &t); err != nil {
+		return t, fmt.Errorf("Error parsing theme JSON file %s: %s", filename, err)
+	}
+	if t.Name == "" {
+		return t, fmt.Errorf("Theme JSON file %s has no name", filename)
+	}
+	return t, nil
+}
+
+// LoadThemes returns the Default theme followed by the themes in all JSON files of directory sorted by name.
+func LoadThemes(directory string) ([]Theme, error) {
+	files, err := filepath.Glob(filepath.Join(directory, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var themes []Theme
+	for _, f := range files {
+		t, err := ParseTheme(f)
+		if err != nil {
+			return nil, err
+		}
+		themes = append(themes, t)
+	}
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+	return append([]Theme{Default}, themes...), nil
+}
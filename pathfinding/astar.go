@@ -40,6 +40,9 @@ func DetermineAstarPath(graph Graph, obstacles Obstacles, start utils.Vec2, goal
 			}
 
 			newCost := costSoFar[current] + calcCost(current, next)
+			if w, ok := graph.(WeightedGraph); ok {
+				newCost += w.Cost(next)
+			}
 
 			c, inCostSoFar := costSoFar[next]
 			if !inCostSoFar || newCost < c {
@@ -0,0 +1,22 @@
+package pathfinding
+
+import "github.com/torlenor/asciiventure/utils"
+
+// Costs are additional costs for entering certain positions, e.g., known traps.
+type Costs interface {
+	Cost(p utils.Vec2) float64
+}
+
+type costGraph struct {
+	Graph
+	costs Costs
+}
+
+func (g costGraph) Cost(p utils.Vec2) float64 {
+	return g.costs.Cost(p)
+}
+
+// WithCosts returns a WeightedGraph which adds costs to the positions of graph.
+func WithCosts(graph Graph, costs Costs) WeightedGraph {
+	return costGraph{Graph: graph, costs: costs}
+}
@@ -10,6 +10,13 @@ type Graph interface {
 	Distance(a utils.Vec2, b utils.Vec2) float64
 }
 
+// WeightedGraph is a Graph with additional costs for entering certain positions,
+// which the path finding algorithm tries to avoid.
+type WeightedGraph interface {
+	Graph
+	Cost(p utils.Vec2) float64
+}
+
 // Obstacles are positions which block the path finding algorithm.
 // En example would be enemies or locked doors.
 type Obstacles interface {
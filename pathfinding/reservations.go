@@ -0,0 +1,49 @@
+package pathfinding
+
+import "github.com/torlenor/asciiventure/utils"
+
+// Reservations hold positions claimed by members of a group, so that they do not all
+// try to use the same positions. The owner of a reservation can be any comparable value.
+type Reservations struct {
+	owners map[utils.Vec2]interface{}
+}
+
+// NewReservations returns empty Reservations.
+func NewReservations() *Reservations {
+	return &Reservations{owners: make(map[utils.Vec2]interface{})}
+}
+
+// Reserve claims p for owner. It returns false if p is already reserved by someone else.
+func (r *Reservations) Reserve(p utils.Vec2, owner interface{}) bool {
+	if r.ReservedByOther(p, owner) {
+		return false
+	}
+	r.owners[p] = owner
+	return true
+}
+
+// ReservedByOther returns true if p is reserved by someone else than owner.
+func (r *Reservations) ReservedByOther(p utils.Vec2, owner interface{}) bool {
+	o, ok := r.owners[p]
+	return ok && o != owner
+}
+
+// Clear removes all reservations.
+func (r *Reservations) Clear() {
+	r.owners = make(map[utils.Vec2]interface{})
+}
+
+type reservedObstacles struct {
+	obstacles    Obstacles
+	reservations *Reservations
+	owner        interface{}
+}
+
+func (o reservedObstacles) Occupied(p utils.Vec2) bool {
+	return o.obstacles.Occupied(p) || o.reservations.ReservedByOther(p, o.owner)
+}
+
+// WithReservations returns Obstacles which additionally block all positions reserved by others than owner.
+func WithReservations(obstacles Obstacles, reservations *Reservations, owner interface{}) Obstacles {
+	return reservedObstacles{obstacles: obstacles, reservations: reservations, owner: owner}
+}
@@ -0,0 +1,343 @@
+package renderers
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"runtime"
+	"unsafe"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/tileset"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// SDLBackend shows the consoles in an SDL window.
+type SDLBackend struct {
+	window   *sdl.Window
+	renderer *Renderer
+
+	glyphRenderers map[tileset.Config]*sdlGlyphRenderer
+}
+
+// NewSDLBackend opens a window with the given title and dimensions in pixels.
+// In fullscreen mode the dimensions are taken from the current display mode instead.
+func NewSDLBackend(title string, w, h int, fullscreen bool) (*SDLBackend, error) {
+	err := sdl.Init(sdl.INIT_VIDEO)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize sdl: %s", err)
+	}
+
+	b := &SDLBackend{glyphRenderers: make(map[tileset.Config]*sdlGlyphRenderer)}
+	if fullscreen {
+		b.window, err = sdl.CreateWindow(title, 0,
+			0, 0, 0, sdl.WINDOW_SHOWN|sdl.WINDOW_FULLSCREEN_DESKTOP)
+	} else {
+		b.window, err = sdl.CreateWindow(title, sdl.WINDOWPOS_CENTERED,
+			sdl.WINDOWPOS_CENTERED, int32(w), int32(h), sdl.WINDOW_SHOWN|sdl.WINDOW_RESIZABLE)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create window: %s", err)
+	}
+
+	sdl.SetHint(sdl.HINT_RENDER_SCALE_QUALITY, "1")
+
+	if runtime.GOOS == "windows" {
+		sdl.SetHint(sdl.HINT_RENDER_DRIVER, "opengl")
+	}
+
+	renderer, err := sdl.CreateRenderer(b.window, -1, sdl.RENDERER_ACCELERATED)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create renderer: %s", err)
+	}
+	b.renderer = NewRenderer(renderer)
+
+	return b, nil
+}
+
+// Renderer returns the renderer of the window.
+func (b *SDLBackend) Renderer() *Renderer {
+	return b.renderer
+}
+
+// Size returns the width and height of the window in pixels.
+func (b *SDLBackend) Size() (w, h int32) {
+	return b.window.GetSize()
+}
+
+// GlyphRenderer returns a console.GlyphRenderer drawing glyphs from the tileset described by c.
+func (b *SDLBackend) GlyphRenderer(c tileset.Config) (console.GlyphRenderer, error) {
+	if r, ok := b.glyphRenderers[c]; ok {
+		return r, nil
+	}
+	ts, err := tileset.Load(c)
+	if err != nil {
+		return nil, err
+	}
+	r, err := b.newGlyphRenderer(ts)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating font from %s: %s", c, err)
+	}
+	b.glyphRenderers[c] = r
+	return r, nil
+}
+
+// TilesetGlyphRenderer returns a console.GlyphRenderer drawing glyphs from t.
+func (b *SDLBackend) TilesetGlyphRenderer(t *tileset.Tileset) (console.GlyphRenderer, error) {
+	return b.newGlyphRenderer(t)
+}
+
+func (b *SDLBackend) newGlyphRenderer(t *tileset.Tileset) (*sdlGlyphRenderer, error) {
+	font, err := NewFontTileset(b.renderer, t)
+	if err != nil {
+		return nil, err
+	}
+	return &sdlGlyphRenderer{renderer: b.renderer, tileset: font, missing: make(map[string]bool)}, nil
+}
+
+// SetMinimumSize sets the size in pixels the window cannot be made smaller than.
+func (b *SDLBackend) SetMinimumSize(w, h int32) {
+	b.window.SetMinimumSize(w, h)
+}
+
+// SetScale sets the scale used for everything rendered afterwards.
+func (b *SDLBackend) SetScale(scaleX, scaleY float32) {
+	b.renderer.SetScale(scaleX, scaleY)
+}
+
+// Clear starts a new frame.
+func (b *SDLBackend) Clear() {
+	b.renderer.GetRenderer().SetClipRect(nil)
+	b.renderer.Clear()
+}
+
+// Screenshot reads the frame drawn since the last call to Clear back from the renderer.
+func (b *SDLBackend) Screenshot() (image.Image, error) {
+	w, h, err := b.renderer.GetRenderer().GetOutputSize()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine output size: %s", err)
+	}
+	img := image.NewRGBA(image.Rect(0, 0, int(w), int(h)))
+	err = b.renderer.GetRenderer().ReadPixels(nil, uint32(sdl.PIXELFORMAT_RGBA32), unsafe.Pointer(&img.Pix[0]), img.Stride)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read pixels: %s", err)
+	}
+	return img, nil
+}
+
+// Present shows the frame.
+func (b *SDLBackend) Present() {
+	b.renderer.Present()
+}
+
+// PollEvent returns the next pending input event or nil if there is none.
+// SDL events without a counterpart are skipped.
+func (b *SDLBackend) PollEvent() console.Event {
+	for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+		switch t := event.(type) {
+		case *sdl.KeyboardEvent:
+			return keyEventFromSDL(t)
+		case *sdl.MouseMotionEvent:
+			return console.MouseEvent{
+				X:      t.X,
+				Y:      t.Y,
+				Left:   t.State&sdl.ButtonLMask() > 0,
+				Middle: t.State&sdl.ButtonMMask() > 0,
+				Right:  t.State&sdl.ButtonRMask() > 0,
+			}
+		case *sdl.MouseButtonEvent:
+			pressed := t.State == sdl.PRESSED
+			return console.MouseEvent{
+				X:      -1,
+				Y:      -1,
+				Left:   pressed && t.Button == sdl.BUTTON_LEFT,
+				Middle: pressed && t.Button == sdl.BUTTON_MIDDLE,
+				Right:  pressed && t.Button == sdl.BUTTON_RIGHT,
+			}
+		case *sdl.WindowEvent:
+			if t.Event == sdl.WINDOWEVENT_SIZE_CHANGED {
+				return console.ResizeEvent{W: t.Data1, H: t.Data2}
+			}
+		case *sdl.QuitEvent:
+			return console.QuitEvent{}
+		}
+	}
+	return nil
+}
+
+// sdlKeys maps the SDL keycodes of keys without a character to Keys.
+var sdlKeys = map[sdl.Keycode]int{
+	sdl.K_UP:    console.KeyUp,
+	sdl.K_DOWN:  console.KeyDown,
+	sdl.K_LEFT:  console.KeyLeft,
+	sdl.K_RIGHT: console.KeyRight,
+	sdl.K_F1:    console.KeyF1,
+	sdl.K_F2:    console.KeyF2,
+	sdl.K_F3:    console.KeyF3,
+	sdl.K_F4:    console.KeyF4,
+	sdl.K_F5:    console.KeyF5,
+	sdl.K_F6:    console.KeyF6,
+	sdl.K_F7:    console.KeyF7,
+	sdl.K_F8:    console.KeyF8,
+	sdl.K_F9:    console.KeyF9,
+	sdl.K_F10:   console.KeyF10,
+	sdl.K_F11:   console.KeyF11,
+	sdl.K_F12:   console.KeyF12,
+}
+
+func keyEventFromSDL(t *sdl.KeyboardEvent) console.KeyEvent {
+	e := console.KeyEvent{Key: int(t.Keysym.Sym), Pressed: t.State == sdl.PRESSED}
+	if k, ok := sdlKeys[t.Keysym.Sym]; ok {
+		e.Key = k
+	}
+	switch t.Keysym.Mod {
+	case sdl.KMOD_LSHIFT:
+		fallthrough
+	case sdl.KMOD_RSHIFT:
+		fallthrough
+	case sdl.KMOD_SHIFT:
+		e.Shift = true
+	case sdl.KMOD_LCTRL:
+		fallthrough
+	case sdl.KMOD_RCTRL:
+		fallthrough
+	case sdl.KMOD_CTRL:
+		e.Ctrl = true
+	case sdl.KMOD_RALT:
+		fallthrough
+	case sdl.KMOD_LALT:
+		fallthrough
+	case sdl.KMOD_ALT:
+		e.Alt = true
+	}
+	return e
+}
+
+// Close destroys the window.
+func (b *SDLBackend) Close() {
+	b.renderer.Destroy()
+	b.window.Destroy()
+	sdl.Quit()
+}
+
+// sdlGlyphRenderer draws glyphs from a TileSet with an SDL renderer.
+type sdlGlyphRenderer struct {
+	renderer *Renderer
+	tileset  TileSet
+
+	// missing holds the chars not found in the tileset, so that they are only reported once
+	missing map[string]bool
+}
+
+func (r *sdlGlyphRenderer) CellSize() (w, h int32) {
+	return r.tileset.GetCharWidth(), r.tileset.GetCharHeight()
+}
+
+func (r *sdlGlyphRenderer) PutGlyph(x, y int32, char string, foregroundColor utils.ColorRGBA, backgroundColor utils.ColorRGBA) {
+	w, h := r.CellSize()
+	dst := &sdl.Rect{X: x, Y: y, W: w, H: h}
+
+	// Render background
+	if backgroundColor.A > 0 {
+		sr := r.renderer.GetRenderer()
+		cr, cg, cb, ca, _ := sr.GetDrawColor()
+		var bm sdl.BlendMode
+		sr.GetDrawBlendMode(&bm)
+		sr.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+		r.renderer.SetDrawColor(backgroundColor.R, backgroundColor.G, backgroundColor.B, backgroundColor.A)
+		sr.FillRect(dst)
+		r.renderer.SetDrawColor(cr, cg, cb, ca)
+		sr.SetDrawBlendMode(bm)
+	}
+
+	// Render foreground
+	if len(char) == 0 {
+		return
+	}
+	g, err := r.tileset.Get(char)
+	if err != nil {
+		if !r.missing[char] {
+			log.Printf("Error getting glyph: %s", err)
+			r.missing[char] = true
+		}
+		return
+	}
+	err = g.T.SetColorMod(foregroundColor.R, foregroundColor.G, foregroundColor.B)
+	if err != nil {
+		log.Printf("Error setting Color in PutGlyph: %s", err)
+	}
+	err = g.T.SetAlphaMod(foregroundColor.A)
+	if err != nil {
+		log.Printf("Error setting Alpha in PutGlyph: %s", err)
+	}
+	err = r.renderer.Copy(g.T, g.Src, dst)
+	if err != nil {
+		log.Printf("Error in PutGlyph: %s", err)
+	}
+}
+
+// NewCanvas returns a console.Canvas backed by a render target texture with the given size in pixels.
+func (r *sdlGlyphRenderer) NewCanvas(w, h int32) (console.Canvas, error) {
+	t, err := r.renderer.GetRenderer().CreateTexture(uint32(sdl.PIXELFORMAT_RGBA8888), sdl.TEXTUREACCESS_TARGET, w, h)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create texture: %s", err)
+	}
+	t.SetBlendMode(sdl.BLENDMODE_BLEND)
+	c := &sdlCanvas{sdlGlyphRenderer: r, texture: t, w: w, h: h}
+	c.Update(func(console.GlyphRenderer) {
+		sr := r.renderer.GetRenderer()
+		sr.SetDrawColor(0, 0, 0, 0)
+		sr.Clear()
+	})
+	return c, nil
+}
+
+// sdlCanvas is a console.Canvas drawing into a texture.
+type sdlCanvas struct {
+	*sdlGlyphRenderer
+	texture *sdl.Texture
+	w, h    int32
+}
+
+// Update makes the texture the render target while draw is called.
+// The scale of the renderer is reset meanwhile, as it is applied when the texture is drawn.
+func (c *sdlCanvas) Update(draw func(r console.GlyphRenderer)) {
+	sr := c.renderer.GetRenderer()
+	scaleX, scaleY := sr.GetScale()
+	cr, cg, cb, ca, _ := sr.GetDrawColor()
+	if err := sr.SetRenderTarget(c.texture); err != nil {
+		log.Printf("Error setting render target: %s", err)
+		return
+	}
+	sr.SetScale(1, 1)
+	draw(c)
+	sr.SetRenderTarget(nil)
+	sr.SetScale(scaleX, scaleY)
+	sr.SetDrawColor(cr, cg, cb, ca)
+}
+
+func (c *sdlCanvas) Draw(x, y int32) {
+	err := c.renderer.Copy(c.texture, nil, &sdl.Rect{X: x, Y: y, W: c.w, H: c.h})
+	if err != nil {
+		log.Printf("Error drawing canvas: %s", err)
+	}
+}
+
+func (c *sdlCanvas) Destroy() {
+	c.texture.Destroy()
+}
+
+// PutGlyph clears the cell at x, y before drawing the glyph into it.
+func (c *sdlCanvas) PutGlyph(x, y int32, char string, foregroundColor utils.ColorRGBA, backgroundColor utils.ColorRGBA) {
+	w, h := c.CellSize()
+	sr := c.renderer.GetRenderer()
+	var bm sdl.BlendMode
+	sr.GetDrawBlendMode(&bm)
+	sr.SetDrawBlendMode(sdl.BLENDMODE_NONE)
+	sr.SetDrawColor(0, 0, 0, 0)
+	sr.FillRect(&sdl.Rect{X: x, Y: y, W: w, H: h})
+	sr.SetDrawBlendMode(bm)
+	c.sdlGlyphRenderer.PutGlyph(x, y, char, foregroundColor, backgroundColor)
+}
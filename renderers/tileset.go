@@ -0,0 +1,86 @@
+package renderers
+
+import (
+	"fmt"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/torlenor/asciiventure/tileset"
+)
+
+// TileSet interface defines all the necessary functions for a Console
+// to retreive the glyphs for rendering.
+type TileSet interface {
+	Get(c string) (RenderGlyph, error)
+	GetCharWidth() int32
+	GetCharHeight() int32
+}
+
+// Char holds the position, width and height of a char texture segment.
+type Char struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+
+	Width  int32 `json:"width"`
+	Height int32 `json:"height"`
+}
+
+func (c Char) String() string {
+	return fmt.Sprintf("X: %d Y: %d W: %d H: %d", c.X, c.Y, c.Width, c.Height)
+}
+
+// FontTileSet provides the actual image texture and a way to retreive the correct texture coordinates
+// to use it.
+type FontTileSet struct {
+	t *sdl.Texture
+
+	charWidth  int32
+	charHeight int32
+
+	characters map[string]Char
+}
+
+// GetCharWidth returns the detected char width in pixel of the font texture.
+func (f FontTileSet) GetCharWidth() int32 {
+	return f.charWidth
+}
+
+// GetCharHeight returns the detected char height in pixel of the font texture.
+func (f FontTileSet) GetCharHeight() int32 {
+	return f.charHeight
+}
+
+// NewFontTileset returns a new FontTileSet with a texture holding the glyphs of the given tileset.
+func NewFontTileset(renderer *Renderer, ts *tileset.Tileset) (*FontTileSet, error) {
+	b := ts.Image.Bounds()
+	texture, err := renderer.GetRenderer().CreateTexture(uint32(sdl.PIXELFORMAT_RGBA32), sdl.TEXTUREACCESS_STATIC, int32(b.Dx()), int32(b.Dy()))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create texture: %s", err)
+	}
+	if err := texture.Update(nil, ts.Image.Pix, ts.Image.Stride); err != nil {
+		texture.Destroy()
+		return nil, fmt.Errorf("Failed to update texture: %s", err)
+	}
+	texture.SetBlendMode(sdl.BLENDMODE_BLEND)
+
+	font := FontTileSet{
+		t:          texture,
+		charWidth:  ts.CharWidth,
+		charHeight: ts.CharHeight,
+		characters: make(map[string]Char),
+	}
+	for char, r := range ts.Glyphs {
+		font.characters[char] = Char{X: int32(r.Min.X), Y: int32(r.Min.Y), Width: int32(r.Dx()), Height: int32(r.Dy())}
+	}
+
+	return &font, nil
+}
+
+// Get returns a glyph with Dst set to render at origin (0,0).
+// Returns true as second value if the operation was successfull.
+func (f *FontTileSet) Get(c string) (RenderGlyph, error) {
+	if a, ok := f.characters[c]; ok {
+		return RenderGlyph{T: f.t, Src: &sdl.Rect{X: int32(a.X), Y: int32(a.Y), W: int32(a.Width), H: int32(a.Height)}}, nil
+	}
+	return RenderGlyph{}, fmt.Errorf("Glyph for char '%s' not found", c)
+}
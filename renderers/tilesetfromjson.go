@@ -0,0 +1,15 @@
+package renderers
+
+import (
+	"github.com/torlenor/asciiventure/tileset"
+)
+
+// NewFontTilesetFromJSON generates a new FontTileSet from the provided
+// image and description file.
+func NewFontTilesetFromJSON(renderer *Renderer, imagePath string, descriptionPath string) (*FontTileSet, error) {
+	ts, err := tileset.LoadJSON(imagePath, descriptionPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewFontTileset(renderer, ts)
+}
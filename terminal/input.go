@@ -0,0 +1,177 @@
+package terminal
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/torlenor/asciiventure/console"
+)
+
+const (
+	esc   = 0x1b
+	ctrlC = 0x03
+)
+
+// csiKeys maps the final byte of CSI and SS3 sequences to keys.
+var csiKeys = map[byte]int{
+	'A': console.KeyUp,
+	'B': console.KeyDown,
+	'C': console.KeyRight,
+	'D': console.KeyLeft,
+	'P': console.KeyF1,
+	'Q': console.KeyF2,
+	'R': console.KeyF3,
+	'S': console.KeyF4,
+}
+
+// tildeKeys maps the parameter of CSI sequences ending with '~' to keys.
+var tildeKeys = map[int]int{
+	11: console.KeyF1,
+	12: console.KeyF2,
+	13: console.KeyF3,
+	14: console.KeyF4,
+	15: console.KeyF5,
+	17: console.KeyF6,
+	18: console.KeyF7,
+	19: console.KeyF8,
+	20: console.KeyF9,
+	21: console.KeyF10,
+	23: console.KeyF11,
+	24: console.KeyF12,
+}
+
+// parseInput translates the bytes read from a terminal in raw mode into events.
+// Unknown sequences are dropped.
+func parseInput(b []byte) []console.Event {
+	var events []console.Event
+	for len(b) > 0 {
+		var e console.Event
+		var n int
+		switch {
+		case b[0] == esc && len(b) > 1 && (b[1] == '[' || b[1] == 'O'):
+			e, n = parseSequence(b)
+		case b[0] == esc && len(b) > 1:
+			// Alt is sent as escape prefix
+			e, n = parseKey(b[1:])
+			if k, ok := e.(console.KeyEvent); ok {
+				k.Alt = true
+				e = k
+			}
+			n++
+		default:
+			e, n = parseKey(b)
+		}
+		if e != nil {
+			events = append(events, e)
+		}
+		b = b[n:]
+	}
+	return events
+}
+
+// parseKey parses a single key at the start of b.
+func parseKey(b []byte) (console.Event, int) {
+	switch c := b[0]; {
+	case c == ctrlC:
+		return console.QuitEvent{}, 1
+	case c == esc:
+		return console.KeyEvent{Key: console.KeyEscape, Pressed: true}, 1
+	case c == '\r' || c == '\n':
+		return console.KeyEvent{Key: console.KeyReturn, Pressed: true}, 1
+	case c == '\t':
+		return console.KeyEvent{Key: console.KeyTab, Pressed: true}, 1
+	case c == 0x7f || c == 0x08:
+		return console.KeyEvent{Key: console.KeyBackspace, Pressed: true}, 1
+	case c >= 0x01 && c <= 0x1a:
+		return console.KeyEvent{Key: int('a' + c - 1), Ctrl: true, Pressed: true}, 1
+	}
+
+	r, n := utf8.DecodeRune(b)
+	if r == utf8.RuneError {
+		return nil, 1
+	}
+	e := console.KeyEvent{Key: int(r), Pressed: true}
+	if unicode.IsUpper(r) {
+		e.Key = int(unicode.ToLower(r))
+		e.Shift = true
+	}
+	return e, n
+}
+
+// parseSequence parses a CSI (ESC [) or SS3 (ESC O) sequence at the start of b.
+func parseSequence(b []byte) (console.Event, int) {
+	// Find the final byte of the sequence
+	end := 2
+	for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+		end++
+	}
+	if end >= len(b) {
+		return nil, len(b)
+	}
+	final := b[end]
+	params := string(b[2:end])
+	n := end + 1
+
+	if strings.HasPrefix(params, "<") {
+		return parseMouse(params[1:], final), n
+	}
+
+	fields := strings.Split(params, ";")
+	e := console.KeyEvent{Pressed: true}
+	if len(fields) > 1 {
+		// Modifiers are encoded as 1 + bitmask of shift (1), alt (2) and ctrl (4)
+		if m, err := strconv.Atoi(fields[1]); err == nil && m > 1 {
+			e.Shift = (m-1)&1 != 0
+			e.Alt = (m-1)&2 != 0
+			e.Ctrl = (m-1)&4 != 0
+		}
+	}
+
+	if final == '~' {
+		p, _ := strconv.Atoi(fields[0])
+		k, ok := tildeKeys[p]
+		if !ok {
+			return nil, n
+		}
+		e.Key = k
+		return e, n
+	}
+	k, ok := csiKeys[final]
+	if !ok {
+		return nil, n
+	}
+	e.Key = k
+	return e, n
+}
+
+// parseMouse parses the parameters of a SGR mouse report "button;x;y" with final byte
+// 'M' for presses and motion and 'm' for releases.
+func parseMouse(params string, final byte) console.Event {
+	fields := strings.Split(params, ";")
+	if len(fields) != 3 {
+		return nil
+	}
+	button, err1 := strconv.Atoi(fields[0])
+	x, err2 := strconv.Atoi(fields[1])
+	y, err3 := strconv.Atoi(fields[2])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil
+	}
+	if button&64 != 0 {
+		// Mouse wheel
+		return nil
+	}
+	if final == 'm' {
+		return console.MouseEvent{X: -1, Y: -1}
+	}
+	pressed := button & 3
+	return console.MouseEvent{
+		X:      int32(x - 1),
+		Y:      int32(y - 1),
+		Left:   pressed == 0,
+		Middle: pressed == 1,
+		Right:  pressed == 2,
+	}
+}
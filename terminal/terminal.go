@@ -0,0 +1,214 @@
+// Package terminal implements a console backend which renders to an ANSI terminal
+// with truecolor support and reads raw keyboard and mouse input from it.
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/tileset"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	enterAltScreen = "\x1b[?1049h"
+	leaveAltScreen = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	// enableMouse reports all mouse motion in SGR encoding
+	enableMouse  = "\x1b[?1003h\x1b[?1006h"
+	disableMouse = "\x1b[?1006l\x1b[?1003l"
+	clearScreen  = "\x1b[2J"
+	resetColors  = "\x1b[0m"
+
+	eventBufferSize = 64
+)
+
+var (
+	defaultForegroundColor = utils.ColorRGBA{R: 255, G: 255, B: 255, A: 255}
+	defaultBackgroundColor = utils.ColorRGBA{A: 255}
+)
+
+type cell struct {
+	char            string
+	foregroundColor utils.ColorRGBA
+	backgroundColor utils.ColorRGBA
+}
+
+var emptyCell = cell{char: " ", foregroundColor: defaultForegroundColor, backgroundColor: defaultBackgroundColor}
+
+// Terminal is a console.Backend where one screen unit is one character cell of the terminal.
+type Terminal struct {
+	in       *os.File
+	out      *bufio.Writer
+	outFile  *os.File
+	oldState *term.State
+
+	width  int32
+	height int32
+
+	// back is the frame currently drawn, front the one shown in the terminal
+	back  []cell
+	front []cell
+
+	events chan console.Event
+	// resized is true if the size of the terminal changed and no ResizeEvent has been returned yet
+	resized bool
+}
+
+// New switches the terminal connected to stdin and stdout into raw mode and returns a backend for it.
+func New() (*Terminal, error) {
+	t := &Terminal{
+		in:      os.Stdin,
+		outFile: os.Stdout,
+		out:     bufio.NewWriter(os.Stdout),
+		events:  make(chan console.Event, eventBufferSize),
+	}
+	if !term.IsTerminal(int(t.in.Fd())) {
+		return nil, fmt.Errorf("stdin is not a terminal")
+	}
+
+	w, h, err := term.GetSize(int(t.outFile.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine terminal size: %s", err)
+	}
+	t.resize(int32(w), int32(h))
+
+	t.oldState, err = term.MakeRaw(int(t.in.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to switch terminal to raw mode: %s", err)
+	}
+
+	t.out.WriteString(enterAltScreen + hideCursor + enableMouse + clearScreen)
+	t.out.Flush()
+
+	go t.readInput()
+
+	return t, nil
+}
+
+func (t *Terminal) resize(w, h int32) {
+	t.width = w
+	t.height = h
+	t.back = make([]cell, w*h)
+	t.front = make([]cell, w*h)
+	for i := range t.back {
+		t.back[i] = emptyCell
+	}
+}
+
+// Size returns the number of columns and rows of the terminal.
+func (t *Terminal) Size() (w, h int32) {
+	return t.width, t.height
+}
+
+// GlyphRenderer returns the terminal itself, as it draws characters in the font of the terminal.
+// The tileset is ignored.
+func (t *Terminal) GlyphRenderer(c tileset.Config) (console.GlyphRenderer, error) {
+	return t, nil
+}
+
+// CellSize returns the size of one cell, which is one character of the terminal.
+func (t *Terminal) CellSize() (w, h int32) {
+	return 1, 1
+}
+
+// PutGlyph draws char into the cell at column x and row y.
+// Colors with an alpha value below 255 are blended with the background already in the cell.
+func (t *Terminal) PutGlyph(x, y int32, char string, foregroundColor utils.ColorRGBA, backgroundColor utils.ColorRGBA) {
+	if x < 0 || x >= t.width || y < 0 || y >= t.height {
+		return
+	}
+	c := &t.back[y*t.width+x]
+	if backgroundColor.A > 0 {
+		c.backgroundColor = blend(c.backgroundColor, backgroundColor)
+	}
+	if len(char) > 0 {
+		c.char = char
+		c.foregroundColor = blend(c.backgroundColor, foregroundColor)
+	}
+}
+
+// blend returns c drawn over the opaque color base.
+func blend(base, c utils.ColorRGBA) utils.ColorRGBA {
+	a := uint32(c.A)
+	mix := func(b, v uint8) uint8 { return uint8((uint32(b)*(255-a) + uint32(v)*a) / 255) }
+	return utils.ColorRGBA{R: mix(base.R, c.R), G: mix(base.G, c.G), B: mix(base.B, c.B), A: 255}
+}
+
+// Clear starts a new frame. If the terminal has been resized in the meantime the whole screen is redrawn.
+func (t *Terminal) Clear() {
+	if w, h, err := term.GetSize(int(t.outFile.Fd())); err == nil && (int32(w) != t.width || int32(h) != t.height) {
+		t.resize(int32(w), int32(h))
+		t.out.WriteString(clearScreen)
+		t.resized = true
+		return
+	}
+	for i := range t.back {
+		t.back[i] = emptyCell
+	}
+}
+
+// Present writes all cells which changed since the last frame to the terminal.
+func (t *Terminal) Present() {
+	var current *cell
+	cursorX, cursorY := int32(-1), int32(-1)
+	for i, c := range t.back {
+		if c == t.front[i] {
+			continue
+		}
+		x, y := int32(i)%t.width, int32(i)/t.width
+		if x != cursorX || y != cursorY {
+			fmt.Fprintf(t.out, "\x1b[%d;%dH", y+1, x+1)
+		}
+		if current == nil || current.foregroundColor != c.foregroundColor {
+			fmt.Fprintf(t.out, "\x1b[38;2;%d;%d;%dm", c.foregroundColor.R, c.foregroundColor.G, c.foregroundColor.B)
+		}
+		if current == nil || current.backgroundColor != c.backgroundColor {
+			fmt.Fprintf(t.out, "\x1b[48;2;%d;%d;%dm", c.backgroundColor.R, c.backgroundColor.G, c.backgroundColor.B)
+		}
+		t.out.WriteString(c.char)
+		t.front[i] = c
+		current = &t.front[i]
+		cursorX, cursorY = x+1, y
+	}
+	t.out.Flush()
+}
+
+// PollEvent returns the next pending input event or nil if there is none.
+func (t *Terminal) PollEvent() console.Event {
+	if t.resized {
+		t.resized = false
+		return console.ResizeEvent{W: t.width, H: t.height}
+	}
+	select {
+	case e := <-t.events:
+		return e
+	default:
+		return nil
+	}
+}
+
+func (t *Terminal) readInput() {
+	buf := make([]byte, 256)
+	for {
+		n, err := t.in.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, e := range parseInput(buf[:n]) {
+			t.events <- e
+		}
+	}
+}
+
+// Close restores the previous state of the terminal.
+func (t *Terminal) Close() {
+	t.out.WriteString(resetColors + disableMouse + showCursor + leaveAltScreen)
+	t.out.Flush()
+	term.Restore(int(t.in.Fd()), t.oldState)
+}
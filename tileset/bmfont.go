@@ -0,0 +1,134 @@
+package tileset
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// bmChar is a char of a BMFont descriptor.
+type bmChar struct {
+	id       rune
+	src      image.Rectangle
+	offset   image.Point
+	xAdvance int
+	page     int
+}
+
+// LoadBMFont loads the BMFont descriptor in text format at path together with the page images it references,
+// which are expected next to the descriptor. Every glyph gets a cell of the line height and the largest advance
+// of all glyphs.
+func LoadBMFont(path string) (*Tileset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open BMFont descriptor: %s", err)
+	}
+	defer f.Close()
+
+	var lineHeight int
+	pageFiles := make(map[int]string)
+	var chars []bmChar
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		tag, attributes := parseBMFontLine(scanner.Text())
+		switch tag {
+		case "common":
+			lineHeight = attributes.int("lineHeight")
+		case "page":
+			pageFiles[attributes.int("id")] = attributes["file"]
+		case "char":
+			x, y := attributes.int("x"), attributes.int("y")
+			chars = append(chars, bmChar{
+				id:       rune(attributes.int("id")),
+				src:      image.Rect(x, y, x+attributes.int("width"), y+attributes.int("height")),
+				offset:   image.Pt(attributes.int("xoffset"), attributes.int("yoffset")),
+				xAdvance: attributes.int("xadvance"),
+				page:     attributes.int("page"),
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Unable to read BMFont descriptor: %s", err)
+	}
+	if lineHeight <= 0 {
+		return nil, fmt.Errorf("BMFont descriptor has no valid lineHeight")
+	}
+
+	pages := make(map[int]*image.NRGBA)
+	for id, file := range pageFiles {
+		if pages[id], err = loadImage(filepath.Join(filepath.Dir(path), file)); err != nil {
+			return nil, fmt.Errorf("Unable to load page %d: %s", id, err)
+		}
+	}
+
+	charWidth := 0
+	for _, c := range chars {
+		if c.xAdvance > charWidth {
+			charWidth = c.xAdvance
+		}
+	}
+	if charWidth <= 0 {
+		return nil, fmt.Errorf("BMFont descriptor contains no chars")
+	}
+
+	a := newAtlas(int32(charWidth), int32(lineHeight), len(chars))
+	for _, c := range chars {
+		page, ok := pages[c.page]
+		if !ok {
+			return nil, fmt.Errorf("Char %d references unknown page %d", c.id, c.page)
+		}
+		a.add(string(c.id), page, c.src, c.offset)
+	}
+	return a.t, nil
+}
+
+// bmAttributes are the key=value pairs of a line of a BMFont descriptor.
+type bmAttributes map[string]string
+
+// int returns the attribute with the given key as integer, or 0 if it is missing or not a number.
+func (a bmAttributes) int(key string) int {
+	v, _ := strconv.Atoi(a[key])
+	return v
+}
+
+// parseBMFontLine splits a line of a BMFont descriptor into its tag and attributes.
+// Values may be quoted to contain spaces.
+func parseBMFontLine(line string) (string, bmAttributes) {
+	line = strings.TrimSpace(line)
+	i := strings.IndexAny(line, " \t")
+	if i < 0 {
+		return line, bmAttributes{}
+	}
+	tag, rest := line[:i], line[i:]
+
+	attributes := bmAttributes{}
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			break
+		}
+		key := rest[:eq]
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if end := strings.IndexAny(rest, " \t"); end >= 0 {
+			value, rest = rest[:end], rest[end:]
+		} else {
+			value, rest = rest, ""
+		}
+		attributes[key] = value
+	}
+	return tag, attributes
+}
@@ -0,0 +1,32 @@
+package tileset
+
+// Dimensions of a code page 437 font image in glyphs.
+const (
+	cp437Columns = 16
+	cp437Rows    = 16
+)
+
+// cp437Layout is the rune of every glyph in a code page 437 font image, row by row.
+// The glyph of NUL is unused.
+var cp437Layout = []rune("\x00☺☻♥♦♣♠•◘○◙♂♀♪♫☼" +
+	"►◄↕‼¶§▬↨↑↓→←∟↔▲▼" +
+	" !\"#$%&'()*+,-./" +
+	"0123456789:;<=>?" +
+	"@ABCDEFGHIJKLMNO" +
+	"PQRSTUVWXYZ[\\]^_" +
+	"`abcdefghijklmno" +
+	"pqrstuvwxyz{|}~⌂" +
+	"ÇüéâäàåçêëèïîìÄÅ" +
+	"ÉæÆôöòûùÿÖÜ¢£¥₧ƒ" +
+	"áíóúñÑªº¿⌐¬½¼¡«»" +
+	"░▒▓│┤╡╢╖╕╣║╗╝╜╛┐" +
+	"└┴┬├─┼╞╟╚╔╩╦╠═╬╧" +
+	"╨╤╥╙╘╒╓╫╪┘┌█▄▌▐▀" +
+	"αßΓπΣσµτΦΘΩδ∞φε∩" +
+	"≡±≥≤⌠⌡÷≈°∙·√ⁿ²■\u00a0")
+
+// LoadCP437 loads the font image at path, which holds 16x16 glyphs in code page 437 order,
+// as used by Dwarf Fortress and many other roguelikes.
+func LoadCP437(path string) (*Tileset, error) {
+	return loadGrid(path, cp437Columns, cp437Rows, cp437Layout)
+}
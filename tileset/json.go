@@ -0,0 +1,77 @@
+package tileset
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"sort"
+)
+
+// charData is the position of a char in the image and its placement relative to the baseline.
+type charData struct {
+	X       int `json:"x"`
+	Y       int `json:"y"`
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+	OriginX int `json:"originX"`
+	OriginY int `json:"originY"`
+	Advance int `json:"advance"`
+}
+
+// charSetData is the JSON description of a font image.
+type charSetData struct {
+	Name       string              `json:"name"`
+	Size       int                 `json:"size"`
+	Bold       bool                `json:"bold"`
+	Italic     bool                `json:"italic"`
+	Width      int                 `json:"width"`
+	Height     int                 `json:"height"`
+	Characters map[string]charData `json:"characters"`
+}
+
+// LoadJSON loads the font image at imagePath with the glyph positions described in the JSON file at descriptionPath.
+// The glyphs are placed on a common baseline in cells as wide as the largest advance.
+func LoadJSON(imagePath string, descriptionPath string) (*Tileset, error) {
+	byteValue, err := ioutil.ReadFile(descriptionPath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read description file for glyph texture: %s", err)
+	}
+	var data charSetData
+	err = json.Unmarshal(byteValue, &data)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse description file for glyph texture: %s", err)
+	}
+	img, err := loadImage(imagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	charWidth, ascent, descent := 0, 0, 0
+	for _, c := range data.Characters {
+		if c.Advance > charWidth {
+			charWidth = c.Advance
+		}
+		if c.OriginY > ascent {
+			ascent = c.OriginY
+		}
+		if c.Height-c.OriginY > descent {
+			descent = c.Height - c.OriginY
+		}
+	}
+	if charWidth <= 0 || ascent+descent <= 0 {
+		return nil, fmt.Errorf("Description file contains no chars")
+	}
+
+	a := newAtlas(int32(charWidth), int32(ascent+descent), len(data.Characters))
+	chars := make([]string, 0, len(data.Characters))
+	for char := range data.Characters {
+		chars = append(chars, char)
+	}
+	sort.Strings(chars)
+	for _, char := range chars {
+		c := data.Characters[char]
+		a.add(char, img, image.Rect(c.X, c.Y, c.X+c.Width, c.Y+c.Height), image.Pt(-c.OriginX, ascent-c.OriginY))
+	}
+	return a.t, nil
+}
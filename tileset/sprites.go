@@ -0,0 +1,137 @@
+package tileset
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"sort"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// spriteRuneBase is the first rune of the Unicode private use area, which is used to reference the sprites
+// in a Sprites tileset without clashing with the chars of the fallback glyphs.
+const spriteRuneBase = 0xE000
+
+// SpriteMapping assigns sprites of a tileset to the chars of map tiles and to entities.
+type SpriteMapping struct {
+	// Tileset holds the sprites, which are referenced by their char in it.
+	Tileset Config `json:"Tileset"`
+	// Size is the width and height in pixels of a cell. If it is 0 the cell size of Tileset is used.
+	Size int32 `json:"Size,omitempty"`
+	// Chars maps the chars of tiles and entity appearances to sprites.
+	Chars map[string]string `json:"Chars"`
+	// Entities maps entity names to sprites. They take precedence over the sprite of the appearance char.
+	Entities map[string]string `json:"Entities"`
+}
+
+// ParseSpriteMapping reads a SpriteMapping from the JSON file at path.
+func ParseSpriteMapping(path string) (SpriteMapping, error) {
+	var m SpriteMapping
+	byteValue, err := ioutil.ReadFile(path)
+	if err != nil {
+		return m, fmt.Errorf("Unable to read sprite mapping: %s", err)
+	}
+	if err := json.Unmarshal(byteValue, &m); err != nil {
+		return m, fmt.Errorf("Unable to parse sprite mapping '%s': %s", path, err)
+	}
+	return m, nil
+}
+
+// Sprites is a Tileset holding the sprites of a SpriteMapping and the glyphs of a fallback tileset for everything
+// without a sprite, all scaled to the same cell size.
+type Sprites struct {
+	*Tileset
+
+	// chars and entities map to the glyph of the sprite in Tileset
+	chars    map[string]string
+	entities map[string]string
+}
+
+// NewSprites loads the sprites of m and combines them with the glyphs of fallback.
+func NewSprites(m SpriteMapping, fallback *Tileset) (*Sprites, error) {
+	sheet, err := Load(m.Tileset)
+	if err != nil {
+		return nil, err
+	}
+	size := m.Size
+	if size <= 0 {
+		size = sheet.CharHeight
+	}
+
+	// Every sprite used in the mapping gets its own glyph, which is referenced by a rune of the private use area
+	var used []string
+	glyphs := make(map[string]string)
+	for _, mapping := range []map[string]string{m.Chars, m.Entities} {
+		for _, sprite := range mapping {
+			if _, ok := glyphs[sprite]; ok {
+				continue
+			}
+			if _, ok := sheet.Glyphs[sprite]; !ok {
+				return nil, fmt.Errorf("Sprite '%s' not found in %s", sprite, m.Tileset)
+			}
+			glyphs[sprite] = ""
+			used = append(used, sprite)
+		}
+	}
+	sort.Strings(used)
+	for i, sprite := range used {
+		glyphs[sprite] = string(rune(spriteRuneBase + i))
+	}
+
+	chars := make([]string, 0, len(fallback.Glyphs))
+	for char := range fallback.Glyphs {
+		chars = append(chars, char)
+	}
+	sort.Strings(chars)
+
+	a := newAtlas(size, size, len(chars)+len(used))
+	for _, char := range chars {
+		fit(a.t.Image, a.next(char), fallback.Image, fallback.Glyphs[char])
+	}
+	for _, sprite := range used {
+		fit(a.t.Image, a.next(glyphs[sprite]), sheet.Image, sheet.Glyphs[sprite])
+	}
+
+	s := &Sprites{Tileset: a.t, chars: make(map[string]string), entities: make(map[string]string)}
+	for char, sprite := range m.Chars {
+		s.chars[char] = glyphs[sprite]
+	}
+	for name, sprite := range m.Entities {
+		s.entities[name] = glyphs[sprite]
+	}
+	return s, nil
+}
+
+// Char returns the glyph of the sprite assigned to char, or char itself if there is none.
+func (s *Sprites) Char(char string) string {
+	if g, ok := s.chars[char]; ok {
+		return g
+	}
+	return char
+}
+
+// Entity returns the glyph of the sprite assigned to the entity with the given name and appearance char,
+// or char itself if there is none.
+func (s *Sprites) Entity(name string, char string) string {
+	if g, ok := s.entities[name]; ok {
+		return g
+	}
+	return s.Char(char)
+}
+
+// fit scales the rectangle src of img into the cell, keeping its aspect ratio and centering it.
+func fit(dst *image.NRGBA, cell image.Rectangle, img *image.NRGBA, src image.Rectangle) {
+	if src.Empty() {
+		return
+	}
+	w, h := cell.Dx(), cell.Dy()
+	if src.Dx()*h > src.Dy()*w {
+		h = src.Dy() * w / src.Dx()
+	} else {
+		w = src.Dx() * h / src.Dy()
+	}
+	min := cell.Min.Add(image.Pt((cell.Dx()-w)/2, (cell.Dy()-h)/2))
+	xdraw.CatmullRom.Scale(dst, image.Rectangle{Min: min, Max: min.Add(image.Pt(w, h))}, img, src, xdraw.Src, nil)
+}
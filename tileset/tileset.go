@@ -0,0 +1,207 @@
+// Package tileset loads the glyphs used to render consoles from different kinds of font files
+// into an image with one cell of equal size per glyph.
+package tileset
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/png" // glyph sheets are PNG files
+	"os"
+)
+
+// Format is the kind of file a tileset is loaded from.
+type Format string
+
+// List of supported Formats.
+const (
+	// FormatLibtcod is an image with 32x8 glyphs in the libtcod layout.
+	FormatLibtcod Format = "libtcod"
+	// FormatCP437 is an image with 16x16 glyphs in code page 437 order.
+	FormatCP437 Format = "cp437"
+	// FormatBMFont is a BMFont descriptor (.fnt) in text format referencing the glyph images.
+	FormatBMFont Format = "bmfont"
+	// FormatJSON is an image with a JSON description of the glyph positions, e.g., ascii_ext_courier.json.
+	FormatJSON Format = "json"
+	// FormatTTF is a TrueType or OpenType font which is rasterized when loading it.
+	FormatTTF Format = "ttf"
+)
+
+// Config describes where to load a tileset from.
+type Config struct {
+	Format Format `json:"Format"`
+	// Path is the image, descriptor or font file, depending on the Format.
+	Path string `json:"Path"`
+	// Description is the JSON description of FormatJSON.
+	Description string `json:"Description,omitempty"`
+	// Size is the font size in pixels of FormatTTF.
+	Size float64 `json:"Size,omitempty"`
+}
+
+func (c Config) String() string {
+	return fmt.Sprintf("%s tileset '%s'", c.Format, c.Path)
+}
+
+// Tileset is an image holding glyphs in cells of equal size.
+// The glyphs are white with their coverage in the alpha channel, so that they can be colored when drawing them.
+type Tileset struct {
+	Image *image.NRGBA
+
+	CharWidth  int32
+	CharHeight int32
+
+	// Glyphs holds the cell of every char in Image.
+	Glyphs map[string]image.Rectangle
+}
+
+// Load loads the tileset described by c.
+func Load(c Config) (*Tileset, error) {
+	var t *Tileset
+	var err error
+	switch c.Format {
+	case FormatLibtcod, "":
+		t, err = LoadLibtcod(c.Path)
+	case FormatCP437:
+		t, err = LoadCP437(c.Path)
+	case FormatBMFont:
+		t, err = LoadBMFont(c.Path)
+	case FormatJSON:
+		t, err = LoadJSON(c.Path, c.Description)
+	case FormatTTF:
+		t, err = LoadTTF(c.Path, c.Size)
+	default:
+		return nil, fmt.Errorf("Unknown tileset format '%s'", c.Format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error loading %s: %s", c, err)
+	}
+	return t, nil
+}
+
+// loadImage loads the image at path as NRGBA, see glyphImage.
+func loadImage(path string) (*image.NRGBA, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open image file: %s", err)
+	}
+	defer f.Close()
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load image file: %s", err)
+	}
+	return glyphImage(src), nil
+}
+
+// glyphImage converts a glyph sheet to NRGBA.
+// Sheets without any transparency, which usually have a black or magenta background, get white glyphs
+// with the brightness of the original pixels as alpha, where magenta is treated as transparent.
+func glyphImage(src image.Image) *image.NRGBA {
+	b := src.Bounds()
+	img := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(img, img.Bounds(), src, b.Min, draw.Src)
+
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 255 {
+			return img
+		}
+	}
+	for i := 0; i < len(img.Pix); i += 4 {
+		r, g, b := img.Pix[i], img.Pix[i+1], img.Pix[i+2]
+		a := r
+		if g > a {
+			a = g
+		}
+		if b > a {
+			a = b
+		}
+		if r == 255 && g == 0 && b == 255 {
+			a = 0
+		}
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = 255, 255, 255, a
+	}
+	return img
+}
+
+// loadGrid loads an image with columns x rows glyphs of equal size. layout holds the rune of every glyph
+// row by row, where 0 marks unused glyphs.
+func loadGrid(path string, columns, rows int, layout []rune) (*Tileset, error) {
+	img, err := loadImage(path)
+	if err != nil {
+		return nil, err
+	}
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if width%columns != 0 {
+		return nil, fmt.Errorf("Not a valid font image, width not dividable by %d", columns)
+	}
+	if height%rows != 0 {
+		return nil, fmt.Errorf("Not a valid font image, height not dividable by %d", rows)
+	}
+
+	dx := width / columns
+	dy := height / rows
+	t := &Tileset{Image: img, CharWidth: int32(dx), CharHeight: int32(dy), Glyphs: make(map[string]image.Rectangle)}
+	for y := 0; y < rows; y++ {
+		for x := 0; x < columns; x++ {
+			r := layout[y*columns+x]
+			if r == 0 {
+				continue
+			}
+			if _, ok := t.Glyphs[string(r)]; !ok {
+				t.Glyphs[string(r)] = image.Rect(x*dx, y*dy, (x+1)*dx, (y+1)*dy)
+			}
+		}
+	}
+	return t, nil
+}
+
+// atlasColumns is the number of cells per row of the images created by atlas.
+const atlasColumns = 32
+
+// atlas builds a Tileset from glyphs of different sizes by drawing each of them into its own cell.
+type atlas struct {
+	t     *Tileset
+	count int
+}
+
+// newAtlas returns an atlas with room for n glyphs in cells of the given size.
+func newAtlas(charWidth, charHeight int32, n int) *atlas {
+	rows := (n + atlasColumns - 1) / atlasColumns
+	return &atlas{t: &Tileset{
+		Image:      image.NewNRGBA(image.Rect(0, 0, atlasColumns*int(charWidth), rows*int(charHeight))),
+		CharWidth:  charWidth,
+		CharHeight: charHeight,
+		Glyphs:     make(map[string]image.Rectangle),
+	}}
+}
+
+// next returns the next free cell of the atlas and assigns it to char.
+func (a *atlas) next(char string) image.Rectangle {
+	w, h := int(a.t.CharWidth), int(a.t.CharHeight)
+	x, y := a.count%atlasColumns*w, a.count/atlasColumns*h
+	a.count++
+	cell := image.Rect(x, y, x+w, y+h)
+	a.t.Glyphs[char] = cell
+	return cell
+}
+
+// add copies the rectangle src of img into the next cell with its upper left corner at offset relative to the cell.
+// Everything outside of the cell is clipped.
+func (a *atlas) add(char string, img *image.NRGBA, src image.Rectangle, offset image.Point) {
+	cell := a.next(char)
+	dst := src.Sub(src.Min).Add(cell.Min).Add(offset)
+	clipped := dst.Intersect(cell)
+	if clipped.Empty() {
+		return
+	}
+	draw.Draw(a.t.Image, clipped, img, src.Min.Add(clipped.Min.Sub(dst.Min)), draw.Src)
+}
+
+// addMask draws white with the coverage of mask into the rectangle dst, clipped to the given cell.
+func (a *atlas) addMask(cell image.Rectangle, dst image.Rectangle, mask image.Image, mp image.Point) {
+	clipped := dst.Intersect(cell)
+	if clipped.Empty() {
+		return
+	}
+	draw.DrawMask(a.t.Image, clipped, image.NewUniform(color.White), image.Point{}, mask, mp.Add(clipped.Min.Sub(dst.Min)), draw.Over)
+}
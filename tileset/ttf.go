@@ -0,0 +1,71 @@
+package tileset
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// defaultTTFSize is the font size in pixels used if none is configured.
+const defaultTTFSize = 16
+
+// LoadTTF rasterizes the TrueType or OpenType font at path with the given size in pixels.
+// It contains every char of the libtcod and code page 437 layouts which is available in the font.
+// The cells are as wide as the advance of 'M', so monospaced fonts give the best results.
+func LoadTTF(path string, size float64) (*Tileset, error) {
+	if size <= 0 {
+		size = defaultTTFSize
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read font file: %s", err)
+	}
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse font file: %s", err)
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create font face: %s", err)
+	}
+	defer face.Close()
+
+	advance, ok := face.GlyphAdvance('M')
+	if !ok {
+		return nil, fmt.Errorf("Font has no glyph for 'M'")
+	}
+	metrics := face.Metrics()
+	charWidth := advance.Ceil()
+	charHeight := (metrics.Ascent + metrics.Descent).Ceil()
+
+	var buf sfnt.Buffer
+	var chars []rune
+	seen := make(map[rune]bool)
+	for _, layout := range [][]rune{libtcodLayout, cp437Layout} {
+		for _, r := range layout {
+			if r == 0 || seen[r] {
+				continue
+			}
+			seen[r] = true
+			if i, err := f.GlyphIndex(&buf, r); err == nil && i != 0 {
+				chars = append(chars, r)
+			}
+		}
+	}
+
+	a := newAtlas(int32(charWidth), int32(charHeight), len(chars))
+	for _, r := range chars {
+		cell := a.next(string(r))
+		dot := fixed.P(cell.Min.X, cell.Min.Y+metrics.Ascent.Ceil())
+		dr, mask, mp, _, ok := face.Glyph(dot, r)
+		if !ok {
+			continue
+		}
+		a.addMask(cell, dr, mask, mp)
+	}
+	return a.t, nil
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/palette"
+)
+
+// KeyHint tells the player what a key does.
+type KeyHint struct {
+	Key         string
+	Description string
+}
+
+// KeyHints shows key hints in one row, e.g., as footer of the screen or a dialog.
+// Hints which do not fit are left out.
+type KeyHints struct {
+	Hints []KeyHint
+}
+
+// NewKeyHints returns a row showing hints.
+func NewKeyHints(hints ...KeyHint) *KeyHints {
+	return &KeyHints{Hints: hints}
+}
+
+// Draw draws the hints into the first row of r.
+func (k *KeyHints) Draw(c *console.MatrixConsole, r Rect) {
+	if r.W <= 0 || r.H <= 0 {
+		return
+	}
+	text, key, bg := palette.Get(palette.UIText), palette.Get(palette.UIKeyHint), palette.Get(palette.UIBackground)
+	fill(c, Rect{X: r.X, Y: r.Y, W: r.W, H: 1}, " ", text, bg)
+	x, maxX := r.X, r.X+r.W
+	for _, h := range k.Hints {
+		width := int32(len([]rune(h.Key))+len([]rune(h.Description))) + 3
+		if x+width > maxX {
+			return
+		}
+		x = putString(c, x, r.Y, maxX, "["+h.Key+"]", key, bg)
+		x = putString(c, x, r.Y, maxX, " "+h.Description+" ", text, bg)
+	}
+}
+
+// Height returns 1 if there are hints to show.
+func (k *KeyHints) Height(width int32) int32 {
+	if len(k.Hints) == 0 {
+		return 0
+	}
+	return 1
+}
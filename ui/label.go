@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/palette"
+)
+
+// Label shows rows of text, which are wrapped at the width of the label.
+// Rows which do not fit below each other are cut off.
+type Label struct {
+	rows []string
+}
+
+// NewLabel returns a label showing rows.
+func NewLabel(rows ...string) *Label {
+	return &Label{rows: rows}
+}
+
+// SetText replaces the rows of the label.
+func (l *Label) SetText(rows ...string) {
+	l.rows = rows
+}
+
+// Text returns the rows of the label.
+func (l *Label) Text() []string {
+	return l.rows
+}
+
+// lines returns the rows wrapped at width.
+func (l *Label) lines(width int32) []string {
+	var lines []string
+	for _, row := range l.rows {
+		lines = append(lines, wrapText(row, int(width))...)
+	}
+	return lines
+}
+
+// Draw draws the text into r.
+func (l *Label) Draw(c *console.MatrixConsole, r Rect) {
+	fg, bg := palette.Get(palette.UIText), palette.Get(palette.UIBackground)
+	for i, line := range l.lines(r.W) {
+		if int32(i) >= r.H {
+			return
+		}
+		putString(c, r.X, r.Y+int32(i), r.X+r.W, line, fg, bg)
+	}
+}
+
+// Height returns the number of wrapped lines.
+func (l *Label) Height(width int32) int32 {
+	return int32(len(l.lines(width)))
+}
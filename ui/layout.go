@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"github.com/torlenor/asciiventure/utils"
+)
+
+const (
+	// minimumColumns and minimumRows are the number of UI cells the screen has at least,
+	// the layout does not get smaller than that
+	minimumColumns = 80
+	minimumRows    = 24
+
+	// minimumTopPanelRows is the height of the top panel on small screens
+	minimumTopPanelRows = 8
+	// barRows is the height of the status bar and the ability bar including their borders
+	barRows = 3
+	// hintRows is the height of the key hint footer
+	hintRows = 1
+)
+
+// Layout decides where the panes of the UI are placed on the screen.
+// All rectangles are in cells of the UI console.
+type Layout struct {
+	// TopPanel shows the character and log panes above the map
+	TopPanel bool
+	// SidePanel shows the mutations, party and inventory panes right of the map
+	SidePanel bool
+
+	Map        Rect
+	Character  Rect
+	Log        Rect
+	StatusBar  Rect
+	KeyHints   Rect
+	Mutations  Rect
+	Party      Rect
+	Inventory  Rect
+	AbilityBar Rect
+	Dialog     Rect
+	Minimap    Rect
+}
+
+// NewLayout returns a layout with all panels shown. Call Update to place the panes.
+func NewLayout() Layout {
+	return Layout{TopPanel: true, SidePanel: true}
+}
+
+// Update places the panes on a screen with the given number of cells.
+// Hidden panels leave their space to the map.
+func (l *Layout) Update(width, height int32) {
+	topHeight := utils.MaxInt32(height/6, minimumTopPanelRows)
+	sideWidth := width / 4
+	mapY, mapW := int32(0), width
+	if l.TopPanel {
+		mapY = topHeight
+	}
+	if l.SidePanel {
+		mapW -= sideWidth
+	}
+
+	l.KeyHints = Rect{X: 0, Y: height - hintRows, W: width, H: hintRows}
+	l.StatusBar = Rect{X: 0, Y: l.KeyHints.Y - barRows, W: width, H: barRows}
+	l.Map = Rect{X: 0, Y: mapY, W: mapW, H: l.StatusBar.Y - mapY}
+
+	// Neighbouring panes overlap by one cell, so that they share their borders.
+	l.Character = Rect{X: 0, Y: 0, W: width/2 + 1, H: topHeight}
+	l.Log = Rect{X: width / 2, Y: 0, W: width - width/2, H: topHeight}
+
+	// The side column is split into the mutations, the party and the inventory pane with a ratio of 5:1:4.
+	top := utils.MaxInt32(mapY-1, 0)
+	columnHeight := l.StatusBar.Y - top
+	l.Mutations = Rect{X: width - sideWidth, Y: top, W: sideWidth, H: columnHeight/2 + 1}
+	l.Party = Rect{X: l.Mutations.X, Y: l.Mutations.Y + l.Mutations.H - 1, W: sideWidth, H: utils.MaxInt32(columnHeight/10, barRows) + 1}
+	l.Inventory = Rect{X: l.Mutations.X, Y: l.Party.Y + l.Party.H - 1, W: sideWidth, H: l.StatusBar.Y - l.Party.Y - l.Party.H + 2}
+
+	l.AbilityBar = Rect{X: 0, Y: l.StatusBar.Y - barRows + 1, W: utils.MinInt32(l.Map.W+1, width), H: barRows}
+	l.Dialog = Rect{X: width / 4, Y: height / 6, W: width / 2, H: 2 * height / 3}
+	l.Minimap = Rect{X: l.Map.X + l.Map.W - width/4, Y: l.Map.Y + 1, W: width / 4, H: height / 4}
+}
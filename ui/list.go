@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/palette"
+)
+
+// List shows items below each other, wrapping long ones, and scrolls when they do not fit.
+// While it has the focus, a cursor marks one item, which can be moved with InputUp and InputDown
+// and selected with InputSelect.
+type List struct {
+	items []string
+
+	cursor int
+	// offset is the first item shown
+	offset  int
+	focused bool
+
+	// Follow keeps the last item visible while the list does not have the focus, e.g., for a log
+	Follow bool
+	// OnSelect is called with the index of the item under the cursor on InputSelect.
+	// The list ignores InputSelect if it is nil.
+	OnSelect func(i int)
+	// Hints are the keys shown while the list has the focus
+	Hints []KeyHint
+}
+
+// NewList returns a list showing items.
+func NewList(items ...string) *List {
+	return &List{items: items}
+}
+
+// SetItems replaces the items of the list. The cursor stays at its index if possible.
+func (l *List) SetItems(items ...string) {
+	l.items = items
+	l.clampCursor()
+}
+
+// Items returns the items of the list.
+func (l *List) Items() []string {
+	return l.items
+}
+
+// Cursor returns the index of the item under the cursor.
+func (l *List) Cursor() int {
+	return l.cursor
+}
+
+// SetCursor moves the cursor to the item with index i.
+func (l *List) SetCursor(i int) {
+	l.cursor = i
+	l.clampCursor()
+}
+
+func (l *List) clampCursor() {
+	if l.cursor >= len(l.items) {
+		l.cursor = len(l.items) - 1
+	}
+	if l.cursor < 0 {
+		l.cursor = 0
+	}
+}
+
+// SetFocused shows or hides the cursor. When the list gets the focus, the cursor starts at the
+// last item of a following list.
+func (l *List) SetFocused(focused bool) {
+	if focused && !l.focused && l.Follow {
+		l.SetCursor(len(l.items) - 1)
+	}
+	l.focused = focused
+}
+
+// HandleInput moves the cursor or selects the item under it.
+func (l *List) HandleInput(in Input) bool {
+	switch in {
+	case InputUp:
+		l.SetCursor(l.cursor - 1)
+		return true
+	case InputDown:
+		l.SetCursor(l.cursor + 1)
+		return true
+	case InputSelect:
+		if l.OnSelect == nil || len(l.items) == 0 {
+			return false
+		}
+		l.OnSelect(l.cursor)
+		return true
+	}
+	return false
+}
+
+// KeyHints returns the Hints of the list.
+func (l *List) KeyHints() []KeyHint {
+	return l.Hints
+}
+
+// scroll updates the offset so that the cursor, or the last item of a following list, is visible
+// in a list with the given number of rows.
+func (l *List) scroll(lines [][]string, rows int32) {
+	target := l.cursor
+	if !l.focused && l.Follow {
+		target = len(lines) - 1
+	}
+	if target < l.offset {
+		l.offset = target
+	}
+	for l.offset < target {
+		var used int32
+		for i := l.offset; i <= target; i++ {
+			used += int32(len(lines[i]))
+		}
+		if used <= rows {
+			break
+		}
+		l.offset++
+	}
+	if l.offset < 0 {
+		l.offset = 0
+	}
+}
+
+// Draw draws the visible items into r.
+func (l *List) Draw(c *console.MatrixConsole, r Rect) {
+	lines := make([][]string, len(l.items))
+	for i, item := range l.items {
+		lines[i] = wrapText(item, int(r.W))
+	}
+	l.scroll(lines, r.H)
+
+	y := r.Y
+	for i := l.offset; i < len(lines) && y < r.Y+r.H; i++ {
+		fg, bg := palette.Get(palette.UIText), palette.Get(palette.UIBackground)
+		if l.focused && i == l.cursor {
+			fg, bg = palette.Get(palette.UISelectedText), palette.Get(palette.UISelectedBackground)
+		}
+		for _, line := range lines[i] {
+			if y >= r.Y+r.H {
+				break
+			}
+			fill(c, Rect{X: r.X, Y: y, W: r.W, H: 1}, " ", fg, bg)
+			putString(c, r.X, y, r.X+r.W, line, fg, bg)
+			y++
+		}
+	}
+}
+
+// Height returns the number of lines needed to show all items.
+func (l *List) Height(width int32) int32 {
+	var h int32
+	for _, item := range l.items {
+		h += int32(len(wrapText(item, int(width))))
+	}
+	return h
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"github.com/torlenor/asciiventure/camera"
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/palette"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// MinimapTile is what the minimap shows for one tile of the map.
+type MinimapTile int
+
+// List of MinimapTiles.
+const (
+	MinimapTileUnknown MinimapTile = iota
+	MinimapTileFloor
+	MinimapTileWall
+	MinimapTilePortal
+	MinimapTilePlayer
+)
+
+var minimapColors = map[MinimapTile]palette.Name{
+	MinimapTileUnknown: palette.MinimapUnknown,
+	MinimapTileFloor:   palette.MinimapFloor,
+	MinimapTileWall:    palette.MinimapWall,
+	MinimapTilePortal:  palette.MinimapPortal,
+	MinimapTilePlayer:  palette.MinimapPlayer,
+}
+
+// Minimap shows the explored parts of a map with one cell per tile.
+// If the backend supports scaling, the cells are drawn smaller than the ones of the map,
+// otherwise only the part of the map around the center fitting into the widget is shown.
+type Minimap struct {
+	renderer console.GlyphRenderer
+	console  *console.MatrixConsole
+	camera   *camera.Camera
+
+	scaler console.Scaler
+	scale  float32
+}
+
+// NewMinimap returns a new Minimap drawing into the dst rectangle in screen units.
+// The scale is only used if scaler is not nil.
+func NewMinimap(r console.GlyphRenderer, scaler console.Scaler, scale float32, dst Rect) *Minimap {
+	if scaler == nil {
+		scale = 1
+	}
+	m := &Minimap{
+		renderer: r,
+		console:  console.NewMatrixConsole(r, 0, 0, 0, 0),
+		camera:   camera.New(camera.ModeCentered, 0, 0),
+		scaler:   scaler,
+		scale:    scale,
+	}
+	m.Resize(dst)
+	return m
+}
+
+// Resize moves the minimap into the dst rectangle in screen units. Call Update afterwards to fill it again.
+func (m *Minimap) Resize(dst Rect) {
+	cw, ch := m.renderer.CellSize()
+	w := int32(float32(dst.W) / m.scale)
+	h := int32(float32(dst.H) / m.scale)
+	m.console.Resize(w, h, w/cw, h/ch)
+	m.console.SetOffset(int32(float32(dst.X)/m.scale), int32(float32(dst.Y)/m.scale))
+	m.camera.SetView(m.console.GetDimensions())
+}
+
+// Update shows a map with the given number of tiles. tile returns what to show at a position
+// and the shown part of the map is centered on center if the whole map does not fit.
+func (m *Minimap) Update(width, height int32, tile func(p utils.Vec2) MinimapTile, center utils.Vec2) {
+	m.camera.SetBounds(width, height)
+	m.camera.Follow(center)
+	m.camera.Snap()
+	ox, oy := m.camera.Offset()
+
+	m.console.Clear()
+	nx, ny := m.console.GetDimensions()
+	for y := int32(0); y < ny; y++ {
+		for x := int32(0); x < nx; x++ {
+			p := utils.Vec2{X: x - ox, Y: y - oy}
+			if p.X < 0 || p.X >= width || p.Y < 0 || p.Y >= height {
+				continue
+			}
+			m.console.SetBackgroundColor(x, y, palette.Get(minimapColors[tile(p)]))
+		}
+	}
+}
+
+// Render draws the minimap.
+func (m *Minimap) Render() {
+	if m.scaler != nil {
+		m.scaler.SetScale(m.scale, m.scale)
+		defer m.scaler.SetScale(1, 1)
+	}
+	m.console.Render()
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"github.com/torlenor/asciiventure/console"
+)
+
+// Dialog describes the content of a Modal.
+type Dialog struct {
+	Title string
+	// Text is shown above the options
+	Text []string
+	// Options can be chosen with the cursor
+	Options []string
+	Hints   []KeyHint
+	// OnSelect is called with the index of the chosen option
+	OnSelect func(option int)
+	// OnCancel is called on InputCancel. The dialog cannot be cancelled if it is nil.
+	OnCancel func()
+}
+
+// Modal is a dialog shown above everything else which takes all input while it is open.
+type Modal struct {
+	panel    *Panel
+	options  *List
+	onCancel func()
+}
+
+// NewModal returns a modal showing d.
+func NewModal(d Dialog) *Modal {
+	options := NewList(d.Options...)
+	options.OnSelect = d.OnSelect
+	options.SetFocused(len(d.Options) > 0)
+	content := NewStack(NewLabel(d.Text...), options, NewKeyHints(d.Hints...))
+	return &Modal{panel: NewPanel(d.Title, content), options: options, onCancel: d.OnCancel}
+}
+
+// Draw draws the modal centered in r with the height of its content, if it fits.
+func (m *Modal) Draw(c *console.MatrixConsole, r Rect) {
+	h := m.Height(r.W)
+	if h < r.H {
+		r.Y += (r.H - h) / 2
+		r.H = h
+	}
+	m.panel.Draw(c, r)
+}
+
+// Height returns the height of the content plus the border.
+func (m *Modal) Height(width int32) int32 {
+	return m.panel.Height(width)
+}
+
+// SetFocused does nothing, as a modal always has the focus.
+func (m *Modal) SetFocused(focused bool) {}
+
+// HandleInput moves the cursor over the options, selects one or cancels the dialog.
+// All other input is swallowed.
+func (m *Modal) HandleInput(in Input) bool {
+	if in == InputCancel {
+		if m.onCancel != nil {
+			m.onCancel()
+		}
+		return true
+	}
+	m.options.HandleInput(in)
+	return true
+}
+
+// KeyHints returns no hints, as the modal shows its own.
+func (m *Modal) KeyHints() []KeyHint {
+	return nil
+}
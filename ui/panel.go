@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/palette"
+)
+
+// Panel draws a border with an optional title around its content and fills the space inside.
+// If the content is Focusable, the panel forwards the focus to it and highlights its border meanwhile.
+type Panel struct {
+	Title   string
+	Content Widget
+
+	focused bool
+}
+
+// NewPanel returns a panel showing content.
+func NewPanel(title string, content Widget) *Panel {
+	return &Panel{Title: title, Content: content}
+}
+
+// Draw draws the border, the title and the content.
+func (p *Panel) Draw(c *console.MatrixConsole, r Rect) {
+	if r.W <= 0 || r.H <= 0 {
+		return
+	}
+	border := palette.Get(palette.UIBorder)
+	if p.focused {
+		border = palette.Get(palette.UIFocusBorder)
+	}
+	background := palette.Get(palette.UIBackground)
+	for y := int32(0); y < r.H; y++ {
+		for x := int32(0); x < r.W; x++ {
+			c.PutCharColor(r.X+x, r.Y+y, borderChar(x, y, r.W, r.H), border, background)
+		}
+	}
+	if len(p.Title) > 0 && r.W > 4 {
+		putString(c, r.X+2, r.Y, r.X+r.W-2, " "+p.Title+" ", border, background)
+	}
+	if p.Content != nil {
+		p.Content.Draw(c, r.inset(1))
+	}
+}
+
+// Height returns the height of the content plus the border.
+func (p *Panel) Height(width int32) int32 {
+	if p.Content == nil {
+		return 2
+	}
+	return p.Content.Height(width-2) + 2
+}
+
+// SetFocused highlights the border and forwards the focus to the content.
+func (p *Panel) SetFocused(focused bool) {
+	p.focused = focused
+	if f, ok := p.Content.(Focusable); ok {
+		f.SetFocused(focused)
+	}
+}
+
+// HandleInput forwards the input to the content.
+func (p *Panel) HandleInput(in Input) bool {
+	if f, ok := p.Content.(Focusable); ok {
+		return f.HandleInput(in)
+	}
+	return false
+}
+
+// KeyHints returns the key hints of the content.
+func (p *Panel) KeyHints() []KeyHint {
+	if f, ok := p.Content.(Focusable); ok {
+		return f.KeyHints()
+	}
+	return nil
+}
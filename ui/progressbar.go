@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/palette"
+)
+
+// ProgressBar shows a value between 0 and a maximum as a bar filled with a color of the palette.
+// The label and the value are written onto the bar.
+type ProgressBar struct {
+	Label string
+	Color palette.Name
+
+	value int32
+	max   int32
+}
+
+// NewProgressBar returns an empty bar with the given label and fill color.
+func NewProgressBar(label string, color palette.Name) *ProgressBar {
+	return &ProgressBar{Label: label, Color: color}
+}
+
+// SetValue changes the value and the maximum of the bar.
+func (b *ProgressBar) SetValue(value, max int32) {
+	b.value = value
+	b.max = max
+}
+
+// Draw draws the bar into the first row of r.
+func (b *ProgressBar) Draw(c *console.MatrixConsole, r Rect) {
+	if r.W <= 0 || r.H <= 0 {
+		return
+	}
+	var filled int32
+	if b.max > 0 && b.value > 0 {
+		filled = r.W * b.value / b.max
+		if filled > r.W {
+			filled = r.W
+		}
+	}
+	fg := palette.Get(palette.UIText)
+	fill(c, Rect{X: r.X, Y: r.Y, W: filled, H: 1}, " ", fg, palette.Get(b.Color))
+	fill(c, Rect{X: r.X + filled, Y: r.Y, W: r.W - filled, H: 1}, " ", fg, palette.Get(palette.UIBarEmpty))
+
+	text := fmt.Sprintf("%s: %d/%d", b.Label, b.value, b.max)
+	for i, ch := range text {
+		x := r.X + int32(i)
+		if x >= r.X+r.W {
+			break
+		}
+		bg := palette.Get(palette.UIBarEmpty)
+		if int32(i) < filled {
+			bg = palette.Get(b.Color)
+		}
+		c.PutCharColor(x, r.Y, string(ch), fg, bg)
+	}
+}
+
+// Height returns 1, as the bar is one row high.
+func (b *ProgressBar) Height(width int32) int32 {
+	return 1
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"github.com/torlenor/asciiventure/console"
+)
+
+// Stack places its children below each other. Every child gets the rows it wants,
+// except for the last one, which gets all remaining rows.
+type Stack struct {
+	Children []Widget
+}
+
+// NewStack returns a stack of children.
+func NewStack(children ...Widget) *Stack {
+	return &Stack{Children: children}
+}
+
+// Draw draws the children into r from top to bottom until r is full.
+func (s *Stack) Draw(c *console.MatrixConsole, r Rect) {
+	y := r.Y
+	for i, child := range s.Children {
+		h := child.Height(r.W)
+		if i == len(s.Children)-1 || y+h > r.Y+r.H {
+			h = r.Y + r.H - y
+		}
+		if h <= 0 {
+			return
+		}
+		child.Draw(c, Rect{X: r.X, Y: y, W: r.W, H: h})
+		y += h
+	}
+}
+
+// Height returns the sum of the heights of the children.
+func (s *Stack) Height(width int32) int32 {
+	var h int32
+	for _, child := range s.Children {
+		h += child.Height(width)
+	}
+	return h
+}
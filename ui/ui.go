@@ -2,136 +2,457 @@ package ui
 
 import (
 	"fmt"
-
-	"github.com/veandco/go-sdl2/sdl"
-	"github.com/veandco/go-sdl2/ttf"
+	"strings"
 
 	"github.com/torlenor/asciiventure/components"
+	"github.com/torlenor/asciiventure/console"
 	"github.com/torlenor/asciiventure/entity"
-	"github.com/torlenor/asciiventure/renderers"
+	"github.com/torlenor/asciiventure/palette"
+	"github.com/torlenor/asciiventure/utils"
 )
 
-// TODO: Come up with a nice UI layout
+// maxLogEntries is the number of log entries kept for scrolling back
+const maxLogEntries = 100
+
+// rowEntry is a log entry which is shown only once when it is repeated.
+type rowEntry struct {
+	text  string
+	count int
+}
+
+// addRow appends row to rows, counting repetitions of the last row instead of adding it again.
+// If there are more than maxRows rows afterwards, the oldest one is removed.
+func addRow(rows []rowEntry, row string, maxRows int) []rowEntry {
+	if len(rows) > 0 && rows[len(rows)-1].text == row {
+		rows[len(rows)-1].count++
+	} else {
+		rows = append(rows, rowEntry{text: row, count: 1})
+	}
+	if len(rows) > maxRows {
+		rows = rows[1:]
+	}
+	return rows
+}
+
+func getText(r rowEntry) string {
+	if r.count > 1 {
+		return fmt.Sprintf("%s (%dx)", r.text, r.count)
+	}
+	return r.text
+}
 
 // UI holds all functions and data related to the UI.
+// The panes are widgets which are drawn into the cells of one console covering the whole screen.
 type UI struct {
-	r *renderers.Renderer
-
-	fontSize int
-	font     *ttf.Font
+	renderer console.GlyphRenderer
+	console  *console.MatrixConsole
 
 	screenWidth  int
 	screenHeight int
 
-	roomRenderPane      sdl.Rect
-	characterWindowRect sdl.Rect
-	logWindowRect       sdl.Rect
-	statusBarRec        sdl.Rect
-	mutationsRect       sdl.Rect
-	inventoryRect       sdl.Rect
-
-	characterWindow  *TextWidget
-	logWindow        *TextWidget
-	statusBar        *TextWidget
-	mutations        *TextWidget
-	inventory        *InventoryWidget
+	layout Layout
+
+	characterPanel *Panel
+	characterInfo  *Label
+	health         *ProgressBar
+	energy         *ProgressBar
+	experience     *ProgressBar
+	characterStats *Label
+
+	logPanel   *Panel
+	log        *List
+	logEntries []rowEntry
+
+	statusPanel *Panel
+	statusBar   *Label
+
+	mutationsPanel *Panel
+	mutations      *List
+
+	inventoryPanel   *Panel
+	inventory        *List
 	inventoryEnabled bool
+
+	abilityBarPanel   *Panel
+	abilityBar        *Label
+	abilityBarEnabled bool
+
+	partyPanel   *Panel
+	party        *Label
+	partyEnabled bool
+
+	keyHints *KeyHints
+	// hints are the key hints shown in the footer while no pane has the focus
+	hints []KeyHint
+
+	// modal is the open dialog, it takes all input
+	modal *Modal
+	// focused is the pane receiving input, if any
+	focused *Panel
+
+	minimap        *Minimap
+	minimapEnabled bool
+
+	// minimapRenderer, minimapScaler and minimapScale are used to create the minimap, see SetupMinimap
+	minimapRenderer console.GlyphRenderer
+	minimapScaler   console.Scaler
+	minimapScale    float32
 }
 
-// NewUI creates a new UI.
-func NewUI(r *renderers.Renderer, font *ttf.Font, fontSize int) *UI {
+// New creates a new UI which renders into the cells of the given GlyphRenderer.
+func New(r console.GlyphRenderer) *UI {
 	ui := &UI{
-		font:     font,
-		fontSize: fontSize,
-		r:        r,
+		renderer: r,
+		console:  console.NewMatrixConsole(r, 0, 0, 0, 0),
+		layout:   NewLayout(),
 	}
 
+	ui.characterInfo = NewLabel()
+	ui.health = NewProgressBar("HP", palette.UIBarHealth)
+	ui.energy = NewProgressBar("Energy", palette.UIBarEnergy)
+	ui.experience = NewProgressBar("XP", palette.UIBarExperience)
+	ui.characterStats = NewLabel()
+	ui.characterPanel = NewPanel("Character", NewStack(ui.characterInfo, ui.health, ui.energy, ui.experience, ui.characterStats))
+
+	ui.log = NewList()
+	ui.log.Follow = true
+	ui.log.Hints = []KeyHint{{Key: "Up/Down", Description: "scroll"}}
+	ui.logPanel = NewPanel("Log", ui.log)
+
+	ui.statusBar = NewLabel()
+	ui.statusPanel = NewPanel("", ui.statusBar)
+
+	ui.mutations = NewList()
+	ui.mutations.Hints = []KeyHint{{Key: "Up/Down", Description: "scroll"}}
+	ui.mutationsPanel = NewPanel("Mutations", ui.mutations)
+
+	ui.inventory = NewList()
+	ui.inventory.Hints = []KeyHint{{Key: "Up/Down", Description: "move"}, {Key: "Enter", Description: "use"}}
+	ui.inventoryPanel = NewPanel("Inventory", ui.inventory)
+
+	ui.abilityBar = NewLabel()
+	ui.abilityBarPanel = NewPanel("Abilities", ui.abilityBar)
+
+	ui.party = NewLabel()
+	ui.partyPanel = NewPanel("Party", ui.party)
+
+	ui.keyHints = NewKeyHints()
+
 	return ui
 }
 
+// SetupMinimap enables the minimap, which draws into the cells of r.
+// If scaler is not nil, the cells are drawn with the given scale.
+// It has to be called before SetScreenDimensions.
+func (ui *UI) SetupMinimap(r console.GlyphRenderer, scaler console.Scaler, scale float32) {
+	ui.minimapRenderer = r
+	ui.minimapScaler = scaler
+	ui.minimapScale = scale
+	ui.minimapEnabled = true
+}
+
+// MinimumSize returns the size in screen units the screen should not be smaller than for the UI to fit.
+func (ui *UI) MinimumSize() (width, height int32) {
+	cw, ch := ui.renderer.CellSize()
+	return minimumColumns * cw, minimumRows * ch
+}
+
+// toScreen converts a rectangle in cells of the UI console into screen units.
+func (ui *UI) toScreen(r Rect) Rect {
+	cw, ch := ui.renderer.CellSize()
+	return Rect{X: r.X * cw, Y: r.Y * ch, W: r.W * cw, H: r.H * ch}
+}
+
+// MapPane returns the rectangle in screen units the map is shown in.
+func (ui *UI) MapPane() Rect {
+	return ui.toScreen(ui.layout.Map)
+}
+
 // SetScreenDimensions sets a new width and height for the current window where the UI is rendered.
 // UI will calculate from that how to position the UI elements on the screen, so make sure it is always
-// current.
+// current. Dimensions smaller than MinimumSize are enlarged to it and the UI is cut off.
 func (ui *UI) SetScreenDimensions(width, height int) {
 	ui.screenWidth = width
 	ui.screenHeight = height
+	ui.relayout()
+}
+
+// relayout places the panes for the current screen dimensions and panel settings.
+func (ui *UI) relayout() {
+	cw, ch := ui.renderer.CellSize()
+	nx := utils.MaxInt32(int32(ui.screenWidth)/cw, minimumColumns)
+	ny := utils.MaxInt32(int32(ui.screenHeight)/ch, minimumRows)
+	ui.console.Resize(nx*cw, ny*ch, nx, ny)
+	ui.layout.Update(nx, ny)
+
+	if ui.minimapRenderer != nil {
+		if ui.minimap == nil {
+			ui.minimap = NewMinimap(ui.minimapRenderer, ui.minimapScaler, ui.minimapScale, ui.toScreen(ui.layout.Minimap))
+		} else {
+			ui.minimap.Resize(ui.toScreen(ui.layout.Minimap))
+		}
+	}
+	ui.checkFocus()
+}
+
+// ToggleTopPanel shows or hides the character and log panes above the map.
+// The map pane changes, see MapPane.
+func (ui *UI) ToggleTopPanel() {
+	ui.layout.TopPanel = !ui.layout.TopPanel
+	ui.relayout()
+}
+
+// ToggleSidePanel shows or hides the mutations, party and inventory panes right of the map.
+// The map pane changes, see MapPane.
+func (ui *UI) ToggleSidePanel() {
+	ui.layout.SidePanel = !ui.layout.SidePanel
+	ui.relayout()
+}
+
+// focusable returns the visible panes which can receive the focus in the order they are cycled through.
+func (ui *UI) focusable() []*Panel {
+	var panels []*Panel
+	if ui.layout.TopPanel {
+		panels = append(panels, ui.logPanel)
+	}
+	if ui.layout.SidePanel {
+		panels = append(panels, ui.mutationsPanel)
+		if ui.inventoryEnabled {
+			panels = append(panels, ui.inventoryPanel)
+		}
+	}
+	return panels
+}
+
+// setFocus gives the focus to p, or to no pane if p is nil.
+func (ui *UI) setFocus(p *Panel) {
+	if ui.focused != nil {
+		ui.focused.SetFocused(false)
+	}
+	ui.focused = p
+	if p != nil {
+		p.SetFocused(true)
+	}
+}
+
+// checkFocus removes the focus from a pane which is not visible anymore.
+func (ui *UI) checkFocus() {
+	for _, p := range ui.focusable() {
+		if p == ui.focused {
+			return
+		}
+	}
+	ui.setFocus(nil)
+}
+
+// FocusNext moves the focus to the next visible pane. After the last one no pane has the focus.
+func (ui *UI) FocusNext() {
+	panels := ui.focusable()
+	next := 0
+	for i, p := range panels {
+		if p == ui.focused {
+			next = i + 1
+		}
+	}
+	if next < len(panels) {
+		ui.setFocus(panels[next])
+	} else {
+		ui.setFocus(nil)
+	}
+}
 
-	ui.roomRenderPane = sdl.Rect{X: int32(ui.screenHeight / 6), Y: 0, W: int32(ui.screenWidth), H: int32(ui.screenHeight - ui.screenHeight/6)}
-	ui.characterWindowRect = sdl.Rect{X: 0, Y: 0, W: int32(ui.screenWidth / 2), H: int32(ui.screenHeight / 6)}
-	ui.logWindowRect = sdl.Rect{X: int32(ui.screenWidth - ui.screenWidth/2 - 1), Y: 0, W: int32(ui.screenWidth/2 + 1), H: int32(ui.screenHeight / 6)}
-	ui.statusBarRec = sdl.Rect{X: 0, Y: int32(ui.screenHeight - ui.fontSize - 16 - 1), W: int32(ui.screenWidth), H: int32(ui.fontSize + 16)}
-	ui.mutationsRect = sdl.Rect{X: int32(ui.screenWidth - ui.screenWidth/4), Y: int32(ui.screenHeight/6 - 1), W: int32(ui.screenWidth / 4), H: int32(3*ui.screenHeight/6 + 1)}
-	ui.inventoryRect = sdl.Rect{X: int32(ui.screenWidth - ui.screenWidth/4), Y: int32(4*ui.screenHeight/6 - 1), W: int32(ui.screenWidth / 4), H: int32(2*int32(ui.screenHeight/6) - ui.statusBarRec.H + 1)}
-
-	ui.characterWindow = NewTextWidget(ui.r, ui.font, &ui.characterWindowRect, true)
-	ui.characterWindow.SetWrapLength(int(ui.characterWindowRect.W - 8))
-	ui.logWindow = NewTextWidget(ui.r, ui.font, &ui.logWindowRect, true)
-	ui.logWindow.SetWrapLength(int(ui.logWindowRect.W - 8))
-	ui.statusBar = NewTextWidget(ui.r, ui.font, &ui.statusBarRec, true)
-	ui.statusBar.SetWrapLength(int(ui.statusBarRec.W - 8))
-	ui.mutations = NewTextWidget(ui.r, ui.font, &ui.mutationsRect, true)
-	ui.mutations.SetWrapLength(int(ui.mutationsRect.W - 8))
-	ui.mutations.AddRow("No mutations")
-	ui.inventory = NewInventoryWidget(ui.r, ui.font, &ui.inventoryRect, true)
-	ui.inventory.SetWrapLength(int(ui.inventoryRect.W - 8))
+// HasFocus returns true if a dialog is open or a pane has the focus, i.e., if the UI wants the input.
+func (ui *UI) HasFocus() bool {
+	return ui.modal != nil || ui.focused != nil
+}
+
+// HandleInput passes the input to the open dialog or the focused pane.
+// InputCancel removes the focus from a pane. It returns false if the input was not used.
+func (ui *UI) HandleInput(in Input) bool {
+	if ui.modal != nil {
+		return ui.modal.HandleInput(in)
+	}
+	if ui.focused == nil {
+		return false
+	}
+	if in == InputCancel {
+		ui.setFocus(nil)
+		return true
+	}
+	return ui.focused.HandleInput(in)
+}
+
+// SetKeyHints sets the hints shown in the footer while no pane has the focus.
+func (ui *UI) SetKeyHints(hints []KeyHint) {
+	ui.hints = hints
 }
 
 // Render the UI.
 func (ui *UI) Render() {
-	ui.characterWindow.Render()
-	ui.logWindow.Render()
-	ui.statusBar.Render()
-	ui.mutations.Render()
-	if ui.inventoryEnabled {
-		ui.inventory.Render()
+	if ui.minimapEnabled && ui.minimap != nil {
+		ui.minimap.Render()
+	}
+
+	c := ui.console
+	l := &ui.layout
+	c.Clear()
+	if l.TopPanel {
+		ui.characterPanel.Draw(c, l.Character)
+		ui.logPanel.Draw(c, l.Log)
+	}
+	if l.SidePanel {
+		ui.mutationsPanel.Draw(c, l.Mutations)
+		if ui.partyEnabled {
+			ui.partyPanel.Draw(c, l.Party)
+		}
+		if ui.inventoryEnabled {
+			ui.inventoryPanel.Draw(c, l.Inventory)
+		}
+	}
+	ui.statusPanel.Draw(c, l.StatusBar)
+	if ui.abilityBarEnabled {
+		ui.abilityBarPanel.Draw(c, l.AbilityBar)
+	}
+
+	ui.keyHints.Hints = ui.hints
+	if ui.focused != nil {
+		ui.keyHints.Hints = append(ui.focused.KeyHints(), KeyHint{Key: "Tab", Description: "next pane"}, KeyHint{Key: "Esc", Description: "back to the map"})
 	}
+	ui.keyHints.Draw(c, l.KeyHints)
+
+	if ui.modal != nil {
+		ui.modal.Draw(c, l.Dialog)
+	}
+	c.Render()
 }
 
 // SetStatusBarText sets a new text in the status bar.
 func (ui *UI) SetStatusBarText(text string) {
 	if len(text) == 0 {
-		ui.statusBar.Clear()
+		ui.statusBar.SetText()
 	} else {
-		ui.statusBar.SetText([]string{text})
+		ui.statusBar.SetText(text)
 	}
 }
 
+// CharacterInfo holds everything shown in the character pane.
+type CharacterInfo struct {
+	Time     uint
+	DayPhase string
+
+	Level       int32
+	XP          int32
+	NextLevelXP int32
+
+	CurrentHP     int32
+	TotalHP       int32
+	CurrentEnergy int32
+	TotalEnergy   int32
+	Instability   int32
+	Hunger        string
+
+	Vision  int32
+	Power   int32
+	Defense int32
+}
+
 // UpdateCharacterPane updates the character infos with the information provided.
-func (ui *UI) UpdateCharacterPane(time uint, currentHP, totalHP, vision, power, defense int32) {
-	ui.characterWindow.SetText([]string{
-		fmt.Sprintf("Time: %d", time),
-		fmt.Sprintf("HP: %d/%d", currentHP, totalHP),
-		fmt.Sprintf("Vision: %d", vision),
-		fmt.Sprintf("Power %d", power),
-		fmt.Sprintf("Defense %d", defense),
-	})
+func (ui *UI) UpdateCharacterPane(c CharacterInfo) {
+	ui.characterInfo.SetText(fmt.Sprintf("Time: %d (%s)  Level: %d", c.Time, c.DayPhase, c.Level))
+	ui.health.SetValue(c.CurrentHP, c.TotalHP)
+	ui.energy.SetValue(c.CurrentEnergy, c.TotalEnergy)
+	ui.experience.SetValue(c.XP, c.NextLevelXP)
+	ui.characterStats.SetText(
+		fmt.Sprintf("Instability: %d  Hunger: %s", c.Instability, c.Hunger),
+		fmt.Sprintf("Vision: %d  Power: %d  Defense: %d", c.Vision, c.Power, c.Defense),
+	)
 }
 
 // UpdateMutationsPane updates the mutation info with the newly provided list.
+// Mutations are grouped by category and free slots are shown as empty entries.
 func (ui *UI) UpdateMutationsPane(mutations components.Mutations) {
-	ui.mutations.Clear()
-	if len(mutations) == 0 {
-		ui.mutations.AddRow("No mutations")
-		return
-	}
-	ui.mutations.AddRow("Mutations:")
-	ui.mutations.AddRow("----------------")
-	for _, m := range mutations {
-		ui.mutations.AddRow(fmt.Sprintf("%s", m))
+	var rows []string
+	for _, category := range components.MutationCategories {
+		indices := mutations.InCategory(category)
+		rows = append(rows, fmt.Sprintf("%s (%d/%d)", category, len(indices), category.Slots()))
+		for slot := 0; slot < category.Slots(); slot++ {
+			if slot < len(indices) {
+				m := mutations[indices[slot]]
+				if m.Activatable {
+					rows = append(rows, fmt.Sprintf("  %d) %s (active)", slot+1, m.Effect))
+				} else {
+					rows = append(rows, fmt.Sprintf("  %d) %s", slot+1, m.Effect))
+				}
+			} else {
+				rows = append(rows, fmt.Sprintf("  %d) -", slot+1))
+			}
+		}
 	}
+	ui.mutations.SetItems(rows...)
 }
 
 // UpdateInventoryPane updates the inventory info with the newly provided list.
 func (ui *UI) UpdateInventoryPane(inventory *entity.Inventory) {
-	ui.inventory.UpdateInventory(inventory)
+	var rows []string
+	for i, name := range inventory.GetInventoryList() {
+		rows = append(rows, fmt.Sprintf("%d) %s", i+1, name))
+	}
+	ui.inventory.SetItems(rows...)
+}
+
+// OnInventorySelect sets the function called with the index of the item chosen in the focused inventory pane.
+func (ui *UI) OnInventorySelect(f func(i int)) {
+	ui.inventory.OnSelect = f
+}
+
+// UpdateAbilityBar updates the ability bar with the provided entries, one per ability.
+// The ability bar is hidden when there are no entries.
+func (ui *UI) UpdateAbilityBar(entries []string) {
+	ui.abilityBarEnabled = len(entries) > 0
+	ui.abilityBar.SetText(strings.Join(entries, "    "))
+}
+
+// ShowDialog shows a modal dialog in the center of the screen, replacing an open one.
+func (ui *UI) ShowDialog(d Dialog) {
+	ui.modal = NewModal(d)
+}
+
+// HideDialog hides the dialog.
+func (ui *UI) HideDialog() {
+	ui.modal = nil
+}
+
+// UpdatePartyPane updates the party pane with the provided entries, one per companion.
+// The party pane is hidden when there are no entries.
+func (ui *UI) UpdatePartyPane(entries []string) {
+	ui.partyEnabled = len(entries) > 0
+	ui.party.SetText(entries...)
 }
 
 // SetInventoryPaneEnabled shows or hides the inventory.
 func (ui *UI) SetInventoryPaneEnabled(enabled bool) {
 	ui.inventoryEnabled = enabled
+	ui.checkFocus()
 }
 
 // AddLogEntry adds a new entry to the log pane.
 func (ui *UI) AddLogEntry(text string) {
-	ui.logWindow.AddRow(text)
+	ui.logEntries = addRow(ui.logEntries, text, maxLogEntries)
+	rows := make([]string, len(ui.logEntries))
+	for i, e := range ui.logEntries {
+		rows[i] = getText(e)
+	}
+	ui.log.SetItems(rows...)
+}
+
+// UpdateMinimap shows a map with the given number of tiles on the minimap, see Minimap.Update.
+func (ui *UI) UpdateMinimap(width, height int32, tile func(p utils.Vec2) MinimapTile, center utils.Vec2) {
+	if ui.minimap != nil {
+		ui.minimap.Update(width, height, tile, center)
+	}
+}
+
+// ToggleMinimap shows or hides the minimap.
+func (ui *UI) ToggleMinimap() {
+	ui.minimapEnabled = !ui.minimapEnabled
 }
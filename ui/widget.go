@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/torlenor/asciiventure/console"
+	"github.com/torlenor/asciiventure/utils"
+)
+
+// Rect is a rectangle in cells of the UI console, or in screen units where noted.
+type Rect struct {
+	X, Y, W, H int32
+}
+
+// inset returns the rectangle shrunk by n cells on every side.
+func (r Rect) inset(n int32) Rect {
+	return Rect{X: r.X + n, Y: r.Y + n, W: utils.MaxInt32(r.W-2*n, 0), H: utils.MaxInt32(r.H-2*n, 0)}
+}
+
+// Widget is an element of the UI. Widgets keep their state between frames and are drawn
+// into the cells of a console every frame.
+type Widget interface {
+	// Draw draws the widget into the rectangle r of c.
+	Draw(c *console.MatrixConsole, r Rect)
+	// Height returns the number of rows the widget wants when it is width cells wide.
+	Height(width int32) int32
+}
+
+// Input is a command of the player which is handled by the focused widget.
+type Input int
+
+// List of Inputs.
+const (
+	InputUp Input = iota
+	InputDown
+	InputSelect
+	InputCancel
+)
+
+// Focusable is a Widget which can receive input.
+type Focusable interface {
+	Widget
+	// SetFocused tells the widget whether it has the focus.
+	SetFocused(focused bool)
+	// HandleInput returns false if the widget does not use the input.
+	HandleInput(in Input) bool
+	// KeyHints returns the keys the widget reacts to while it has the focus.
+	KeyHints() []KeyHint
+}
+
+// putString writes s into the cells starting at x, y, but not further than maxX.
+func putString(c *console.MatrixConsole, x, y, maxX int32, s string, foregroundColor, backgroundColor utils.ColorRGBA) int32 {
+	for _, r := range s {
+		if x >= maxX {
+			break
+		}
+		c.PutCharColor(x, y, string(r), foregroundColor, backgroundColor)
+		x++
+	}
+	return x
+}
+
+// fill sets all cells of r to char with the given colors.
+func fill(c *console.MatrixConsole, r Rect, char string, foregroundColor, backgroundColor utils.ColorRGBA) {
+	for y := r.Y; y < r.Y+r.H; y++ {
+		for x := r.X; x < r.X+r.W; x++ {
+			c.PutCharColor(x, y, char, foregroundColor, backgroundColor)
+		}
+	}
+}
+
+// borderChar returns the char at x, y of a border around a box of nx x ny cells.
+func borderChar(x, y, nx, ny int32) string {
+	switch {
+	case x == 0 && y == 0:
+		return "┌"
+	case x == nx-1 && y == 0:
+		return "┐"
+	case x == 0 && y == ny-1:
+		return "└"
+	case x == nx-1 && y == ny-1:
+		return "┘"
+	case y == 0 || y == ny-1:
+		return "─"
+	case x == 0 || x == nx-1:
+		return "│"
+	}
+	return " "
+}
+
+// wrapText splits text into lines of at most width characters, breaking at spaces where possible.
+// The indentation of the first line of a paragraph is kept.
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		trimmed := strings.TrimLeft(paragraph, " ")
+		line := []rune(paragraph[:len(paragraph)-len(trimmed)])
+		for i, word := range strings.Split(trimmed, " ") {
+			w := []rune(word)
+			if len(line) > 0 && len(line)+1+len(w) > width {
+				lines = append(lines, string(line))
+				line = []rune{}
+			}
+			if len(line) > 0 && i > 0 {
+				line = append(line, ' ')
+			}
+			line = append(line, w...)
+			for width > 0 && len(line) > width {
+				lines = append(lines, string(line[:width]))
+				line = line[width:]
+			}
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}